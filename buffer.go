@@ -0,0 +1,107 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// fetchUint8 returns numBytes uint8 values, served from the local pool when
+// buffering or a seed file is enabled and fetched directly otherwise.
+// Requests carrying a per-request API key bypass the pool so one tenant's
+// quota never serves another.
+func (c *QRNGClient) fetchUint8(ctx context.Context, numBytes int) ([]uint8, error) {
+	if _, perKey := apiKeyFromContext(ctx); c.BufferSize <= 0 && c.seedFile == "" || perKey {
+		return c.requestUint8(ctx, numBytes)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if rec := provenanceFromContext(ctx); rec != nil && len(c.pool) > 0 {
+		rec.pooled()
+	}
+	if err := c.fillPoolLocked(ctx, numBytes); err != nil {
+		return nil, err
+	}
+
+	result := make([]uint8, numBytes)
+	copy(result, c.pool)
+	c.pool = c.pool[numBytes:]
+	return result, nil
+}
+
+// fillPoolLocked refills the pool until it holds at least n values.
+// The caller must hold c.mu.
+func (c *QRNGClient) fillPoolLocked(ctx context.Context, n int) error {
+	for len(c.pool) < n {
+		length := max(c.BufferSize, c.MinFetchSize, n-len(c.pool))
+		length = min(length, c.maxLength())
+
+		data, err := c.requestUint8(ctx, length)
+		if err != nil {
+			return err
+		}
+		c.pool = append(c.pool, data...)
+	}
+	return nil
+}
+
+// requestUint8 fetches length uint8 values from the API and passes them
+// through the PostProcess hook, if any
+func (c *QRNGClient) requestUint8(ctx context.Context, length int) ([]uint8, error) {
+	qr, err := c.doRequest(ctx, length, "uint8", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	data := convertUint8(qr.Data)
+	if c.PostProcess == nil {
+		return data, nil
+	}
+
+	processed, err := c.PostProcess(data)
+	if err != nil {
+		return nil, fmt.Errorf("post-processing failed: %w", err)
+	}
+	if len(processed) != len(data) {
+		return nil, fmt.Errorf("post-processing returned %d bytes, expected %d", len(processed), len(data))
+	}
+	return processed, nil
+}
+
+// Warmup establishes a keep-alive connection to the API so the first real
+// request does not pay the DNS and TLS handshake cost.
+//
+// Warmup always makes a one-byte request, even when there is nothing to
+// prefetch. When buffering is enabled (BufferSize > 0), that byte is added
+// to the pool, which is then topped up until it holds at least bytes values
+// for later byte-oriented calls to consume before going to the network.
+// Without buffering, or when ctx carries a ContextWithAPIKey key, bytes is
+// ignored and the one-byte result is discarded, so one tenant's quota never
+// fills the shared pool.
+func (c *QRNGClient) Warmup(ctx context.Context, bytes int) error {
+	if bytes < 0 {
+		return errors.New("bytes must not be negative")
+	}
+
+	ctx, cancel := withTimeout(ctx, c.Timeouts.Probe)
+	defer cancel()
+
+	if _, perKey := apiKeyFromContext(ctx); c.BufferSize <= 0 || perKey {
+		_, err := c.doRequest(ctx, 1, "uint8", 0)
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// always touch the network, even when the pool is already full
+	data, err := c.requestUint8(ctx, 1)
+	if err != nil {
+		return err
+	}
+	c.pool = append(c.pool, data...)
+
+	return c.fillPoolLocked(ctx, bytes)
+}
@@ -0,0 +1,232 @@
+package qrng_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func newCountingServer(t *testing.T, calls *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		length, _ := strconv.Atoi(r.URL.Query().Get("length"))
+		data := make([]string, length)
+		for i := range data {
+			data[i] = strconv.Itoa(i % 256)
+		}
+		fmt.Fprintf(w, `{"type":"uint8","length":%d,"data":[%s],"success":true}`, length, strings.Join(data, ","))
+	}))
+}
+
+func TestBufferedClient(t *testing.T) {
+	t.Run("serves from pool", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.BufferSize = 16
+
+		first, err := client.GetRandomUint8(4)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		second, err := client.GetRandomUint8(4)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if fmt.Sprint(first) != "[0 1 2 3]" || fmt.Sprint(second) != "[4 5 6 7]" {
+			t.Errorf("Unexpected pool contents: %v %v", first, second)
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 request, got %d", calls)
+		}
+	})
+}
+
+func TestMinFetchSize(t *testing.T) {
+	t.Run("rounds refills up", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.BufferSize = 1
+		client.MinFetchSize = 8
+
+		var got []uint8
+		for i := 0; i < 8; i++ {
+			data, err := client.GetRandomUint8(1)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got = append(got, data...)
+		}
+
+		if fmt.Sprint(got) != "[0 1 2 3 4 5 6 7]" {
+			t.Errorf("Unexpected pool contents: %v", got)
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 request, got %d", calls)
+		}
+	})
+
+	t.Run("ignored without buffering", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.MinFetchSize = 8
+
+		for i := 0; i < 2; i++ {
+			data, err := client.GetRandomUint8(1)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if fmt.Sprint(data) != "[0]" {
+				t.Errorf("Expected [0], got %v", data)
+			}
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 requests, got %d", calls)
+		}
+	})
+}
+
+func TestWarmup(t *testing.T) {
+	t.Run("prefills buffer", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.BufferSize = 8
+
+		if err := client.Warmup(context.Background(), 8); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		warmupCalls := calls
+		if _, err := client.GetRandomBits(64); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if calls != warmupCalls {
+			t.Errorf("Expected no requests after warmup, got %d", calls-warmupCalls)
+		}
+	})
+
+	t.Run("always makes a request", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.BufferSize = 8
+
+		for range 2 {
+			if err := client.Warmup(context.Background(), 0); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 requests, got %d", calls)
+		}
+	})
+
+	t.Run("per-request key skips the pool", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.BufferSize = 8
+
+		ctx := qrng.ContextWithAPIKey(context.Background(), "tenant")
+		if err := client.Warmup(ctx, 8); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected the tenant warmup not to fill the pool, made %d requests", calls)
+		}
+	})
+
+	t.Run("unbuffered client", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		if err := client.Warmup(context.Background(), 100); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 request, got %d", calls)
+		}
+	})
+}
+
+func TestPostProcess(t *testing.T) {
+	t.Run("transforms fetched bytes", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.BufferSize = 8
+		client.PostProcess = func(data []byte) ([]byte, error) {
+			for i := range data {
+				data[i] ^= 0xff
+			}
+			return data, nil
+		}
+
+		data, err := client.GetRandomUint8(3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(data) != "[255 254 253]" {
+			t.Errorf("Expected [255 254 253], got %v", data)
+		}
+	})
+
+	t.Run("errors surfaced", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		client.PostProcess = func([]byte) ([]byte, error) { return nil, errors.New("mixer offline") }
+		if _, err := client.GetRandomUint8(3); err == nil {
+			t.Error("Expected post-processing error")
+		}
+
+		client.PostProcess = func(data []byte) ([]byte, error) { return data[:1], nil }
+		if _, err := client.GetRandomUint8(3); err == nil {
+			t.Error("Expected error for shortened output")
+		}
+	})
+}
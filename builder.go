@@ -0,0 +1,112 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RequestBuilder assembles a single API request with chainable setters,
+// for one-off requests that would otherwise need GetRandomTyped's
+// positional arguments. Create one with NewRequest; nothing is sent until
+// Do. A builder is not safe for concurrent use.
+type RequestBuilder struct {
+	client    *QRNGClient
+	ctx       context.Context
+	dataType  string
+	length    int
+	blockSize int
+	params    url.Values
+}
+
+// NewRequest starts a request for one uint8 value; use the setters to
+// change the type, length and other parameters
+func (c *QRNGClient) NewRequest() *RequestBuilder {
+	return &RequestBuilder{
+		client:   c,
+		ctx:      context.Background(),
+		dataType: "uint8",
+		length:   1,
+	}
+}
+
+// Type sets the API data type, such as "uint8", "uint16", "hex8" or "hex16"
+func (b *RequestBuilder) Type(dataType string) *RequestBuilder {
+	b.dataType = dataType
+	return b
+}
+
+// Length sets how many values (or hex blocks) to request
+func (b *RequestBuilder) Length(length int) *RequestBuilder {
+	b.length = length
+	return b
+}
+
+// BlockSize sets the number of bytes per block for the hex types. Like the
+// typed methods, Do ignores it for other types.
+func (b *RequestBuilder) BlockSize(size int) *RequestBuilder {
+	b.blockSize = size
+	return b
+}
+
+// Param adds an extra query parameter, on top of the client's ExtraParams.
+// length, type and size are controlled by their own setters and cannot be
+// overridden here.
+func (b *RequestBuilder) Param(key, value string) *RequestBuilder {
+	if b.params == nil {
+		b.params = url.Values{}
+	}
+	b.params.Add(key, value)
+	return b
+}
+
+// WithContext sets the context for the request, which also carries any
+// per-request API key, request ID or idempotency token
+func (b *RequestBuilder) WithContext(ctx context.Context) *RequestBuilder {
+	b.ctx = ctx
+	return b
+}
+
+// Do validates the request with the rules of the typed methods and sends
+// it, retrying as configured. It always fetches from the API rather than
+// the buffered pool and returns the raw response.
+func (b *RequestBuilder) Do() (*QRNGResponse, error) {
+	if b.ctx == nil {
+		return nil, errors.New("context must not be nil")
+	}
+	if limit := b.client.maxLength(); b.length < 1 || b.length > limit {
+		return nil, fmt.Errorf("length must be between 1 and %d", limit)
+	}
+
+	var params url.Values
+	for k, v := range b.params {
+		if k == "length" || k == "type" || k == "size" {
+			return nil, fmt.Errorf("parameter %q must be set with its own method", k)
+		}
+		if params == nil {
+			params = url.Values{}
+		}
+		params[k] = append([]string(nil), v...)
+	}
+
+	switch {
+	case strings.HasPrefix(b.dataType, "hex"):
+		if b.dataType != "hex8" && b.dataType != "hex16" {
+			return nil, ErrInvalidHexType
+		}
+		if b.blockSize < 1 || b.blockSize > 10 {
+			return nil, ErrInvalidBlockSize
+		}
+		if params == nil {
+			params = url.Values{}
+		}
+		params.Set("size", strconv.Itoa(b.blockSize))
+	case b.dataType == "":
+		return nil, errors.New("type must not be empty")
+	}
+
+	return b.client.doRequestParams(b.ctx, b.length, b.dataType, params)
+}
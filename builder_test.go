@@ -0,0 +1,114 @@
+package qrng_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestRequestBuilder(t *testing.T) {
+	t.Run("sends the configured request", func(t *testing.T) {
+		var query url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			fmt.Fprintln(w, `{"type":"hex16","length":2,"data":[4660,22136],"success":true}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		qr, err := client.NewRequest().
+			Type("hex16").
+			Length(2).
+			BlockSize(4).
+			Param("note", "x").
+			WithContext(context.Background()).
+			Do()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(qr.Data) != 2 {
+			t.Errorf("Expected 2 values, got %v", qr.Data)
+		}
+		for k, want := range map[string]string{"type": "hex16", "length": "2", "size": "4", "note": "x"} {
+			if got := query.Get(k); got != want {
+				t.Errorf("Expected %s=%s, got %q", k, want, got)
+			}
+		}
+	})
+
+	t.Run("defaults to one uint8", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":1,"data":[9],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		qr, err := client.NewRequest().Do()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(qr.Data) != "[9]" {
+			t.Errorf("Expected [9], got %v", qr.Data)
+		}
+	})
+
+	t.Run("ignores block size for non-hex types", func(t *testing.T) {
+		var query url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			fmt.Fprintln(w, `{"type":"uint16","length":1,"data":[513],"success":true}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		if _, err := client.NewRequest().Type("uint16").Length(1).BlockSize(4).Do(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if query.Has("size") {
+			t.Errorf("Expected no size parameter, got %q", query.Get("size"))
+		}
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Unexpected request %s", r.URL.RawQuery)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		tests := []struct {
+			name    string
+			builder *qrng.RequestBuilder
+			want    error
+		}{
+			{"zero length", client.NewRequest().Length(0), nil},
+			{"too long", client.NewRequest().Length(1025), nil},
+			{"bad hex type", client.NewRequest().Type("hex32").BlockSize(1), qrng.ErrInvalidHexType},
+			{"missing block size", client.NewRequest().Type("hex8"), qrng.ErrInvalidBlockSize},
+			{"reserved param", client.NewRequest().Param("length", "5"), nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := tt.builder.Do()
+				if err == nil {
+					t.Fatal("Expected validation error")
+				}
+				if tt.want != nil && !errors.Is(err, tt.want) {
+					t.Errorf("Expected %v, got %v", tt.want, err)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package qrng
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Suit is a playing-card suit
+type Suit int
+
+const (
+	Clubs Suit = iota
+	Diamonds
+	Hearts
+	Spades
+)
+
+func (s Suit) String() string {
+	switch s {
+	case Clubs:
+		return "♣"
+	case Diamonds:
+		return "♦"
+	case Hearts:
+		return "♥"
+	case Spades:
+		return "♠"
+	}
+	return fmt.Sprintf("Suit(%d)", int(s))
+}
+
+// Rank is a playing-card rank, numbered 2 through 14 with aces high
+type Rank int
+
+const (
+	Two Rank = iota + 2
+	Three
+	Four
+	Five
+	Six
+	Seven
+	Eight
+	Nine
+	Ten
+	Jack
+	Queen
+	King
+	Ace
+)
+
+func (r Rank) String() string {
+	switch {
+	case r >= Two && r <= Ten:
+		return fmt.Sprint(int(r))
+	case r == Jack:
+		return "J"
+	case r == Queen:
+		return "Q"
+	case r == King:
+		return "K"
+	case r == Ace:
+		return "A"
+	}
+	return fmt.Sprintf("Rank(%d)", int(r))
+}
+
+// Card is a card from a standard 52-card deck
+type Card struct {
+	Rank Rank
+	Suit Suit
+}
+
+func (c Card) String() string {
+	return c.Rank.String() + c.Suit.String()
+}
+
+const deckSize = 52
+
+var ErrDeckExhausted = errors.New("not enough cards in a standard deck")
+
+// DealStandardDeck returns all 52 cards in quantum-shuffled order
+func (c *QRNGClient) DealStandardDeck() ([]Card, error) {
+	deck := make([]Card, 0, deckSize)
+	for s := Clubs; s <= Spades; s++ {
+		for r := Two; r <= Ace; r++ {
+			deck = append(deck, Card{Rank: r, Suit: s})
+		}
+	}
+
+	if err := c.Shuffle(len(deck), func(i, j int) {
+		deck[i], deck[j] = deck[j], deck[i]
+	}); err != nil {
+		return nil, err
+	}
+	return deck, nil
+}
+
+// DealHands shuffles a standard deck and deals cardsEach cards to each of
+// numPlayers players, one card at a time in rotation
+func (c *QRNGClient) DealHands(numPlayers, cardsEach int) ([][]Card, error) {
+	if numPlayers < 1 || cardsEach < 1 {
+		return nil, errors.New("numPlayers and cardsEach must be at least 1")
+	}
+	if numPlayers > deckSize/cardsEach {
+		return nil, ErrDeckExhausted
+	}
+
+	deck, err := c.DealStandardDeck()
+	if err != nil {
+		return nil, err
+	}
+
+	hands := make([][]Card, numPlayers)
+	for p := range hands {
+		hands[p] = make([]Card, cardsEach)
+	}
+	for i := 0; i < numPlayers*cardsEach; i++ {
+		hands[i%numPlayers][i/numPlayers] = deck[i]
+	}
+	return hands, nil
+}
@@ -0,0 +1,80 @@
+package qrng_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestDealStandardDeck(t *testing.T) {
+	var calls int32
+	server := newCountingServer(t, &calls)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	deck, err := client.DealStandardDeck()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(deck) != 52 {
+		t.Fatalf("Expected 52 cards, got %d", len(deck))
+	}
+
+	seen := make(map[qrng.Card]bool)
+	for _, card := range deck {
+		if seen[card] {
+			t.Errorf("Duplicate card %v", card)
+		}
+		seen[card] = true
+	}
+	if atomic.LoadInt32(&calls) > 5 {
+		t.Errorf("Expected a batched shuffle, made %d requests", calls)
+	}
+}
+
+func TestDealHands(t *testing.T) {
+	var calls int32
+	server := newCountingServer(t, &calls)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	t.Run("deals distinct hands", func(t *testing.T) {
+		hands, err := client.DealHands(4, 13)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		seen := make(map[qrng.Card]bool)
+		for _, hand := range hands {
+			if len(hand) != 13 {
+				t.Errorf("Expected 13 cards, got %d", len(hand))
+			}
+			for _, card := range hand {
+				if seen[card] {
+					t.Errorf("Card %v dealt twice", card)
+				}
+				seen[card] = true
+			}
+		}
+	})
+
+	t.Run("deck exhausted", func(t *testing.T) {
+		if _, err := client.DealHands(6, 9); !errors.Is(err, qrng.ErrDeckExhausted) {
+			t.Errorf("Expected ErrDeckExhausted, got %v", err)
+		}
+	})
+}
+
+func TestCardString(t *testing.T) {
+	if s := (qrng.Card{Rank: qrng.Ace, Suit: qrng.Spades}).String(); s != "A♠" {
+		t.Errorf("Expected A♠, got %s", s)
+	}
+	if s := (qrng.Card{Rank: qrng.Ten, Suit: qrng.Hearts}).String(); s != "10♥" {
+		t.Errorf("Expected 10♥, got %s", s)
+	}
+}
@@ -0,0 +1,62 @@
+package qrng
+
+import (
+	"context"
+)
+
+type contextKey int
+
+const (
+	apiKeyContextKey contextKey = iota
+	idempotencyTokenContextKey
+	requestIDContextKey
+	provenanceContextKey
+	witnessContextKey
+)
+
+// requestIDHeader carries the ContextWithRequestID value to the API
+const requestIDHeader = "X-Request-ID"
+
+// ContextWithAPIKey returns a context whose requests authenticate with apiKey
+// instead of the client's configured key. This lets one shared client, and
+// its connection pool, serve several tenants. Such requests bypass the
+// buffered pool.
+func ContextWithAPIKey(ctx context.Context, apiKey string) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey, apiKey)
+}
+
+func apiKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(apiKeyContextKey).(string)
+	return key, ok
+}
+
+// ContextWithIdempotencyToken marks requests made with ctx as one logical
+// operation. While a request with the same token and parameters is in
+// flight, identical requests wait for it and receive the same data instead
+// of issuing another HTTP call, which keeps caller-side and internal retries
+// from consuming extra quota.
+//
+// Requests without a token are never merged: two independent callers must
+// not receive the same random values.
+func ContextWithIdempotencyToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, idempotencyTokenContextKey, token)
+}
+
+func idempotencyTokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(idempotencyTokenContextKey).(string)
+	return token, ok
+}
+
+// ContextWithRequestID attaches a correlation ID to requests made with ctx.
+// It is sent as the X-Request-ID header and reported in the RequestID field
+// of OnRequest events, so QRNG calls can be tied to the surrounding request
+// in logs. A buffered pool refill carries the ID of the call that triggered
+// it.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+func requestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContextKey).(string)
+	return id, ok
+}
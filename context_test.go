@@ -0,0 +1,88 @@
+package qrng_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestContextWithAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		length, _ := strconv.Atoi(r.URL.Query().Get("length"))
+		data := strings.TrimSuffix(strings.Repeat(strconv.Itoa(len(r.Header.Get("x-api-key")))+",", length), ",")
+		fmt.Fprintf(w, `{"type":"uint8","length":%d,"data":[%s],"success":true}`, length, data)
+	}))
+	defer server.Close()
+
+	t.Run("overrides client key", func(t *testing.T) {
+		client := qrng.NewClientWithAPIKey("default")
+		client.APIEndpoint = server.URL
+		client.BufferSize = 32
+
+		ctx := qrng.ContextWithAPIKey(context.Background(), "tenant-key")
+		data, err := client.GetRandomUint8Context(ctx, 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if data[0] != uint8(len("tenant-key")) {
+			t.Errorf("Expected tenant key to be sent, server saw length %d", data[0])
+		}
+
+		data, err = client.GetRandomUint8(1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if data[0] != uint8(len("default")) {
+			t.Errorf("Expected default key to be sent, server saw length %d", data[0])
+		}
+	})
+
+	t.Run("satisfies missing client key", func(t *testing.T) {
+		client := qrng.NewClientWithAPIKey("")
+		client.APIEndpoint = server.URL
+
+		ctx := qrng.ContextWithAPIKey(context.Background(), "tenant-key")
+		if _, err := client.GetRandomUint8Context(ctx, 1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func TestContextWithRequestID(t *testing.T) {
+	var seen string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header.Get("X-Request-ID")
+		fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[1],"success":true}`)
+	}))
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	var events []qrng.RequestEvent
+	client.OnRequest(func(ev qrng.RequestEvent) { events = append(events, ev) })
+
+	ctx := qrng.ContextWithRequestID(context.Background(), "req-42")
+	if _, err := client.GetRandomUint8Context(ctx, 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if seen != "req-42" {
+		t.Errorf("Expected header req-42, got %q", seen)
+	}
+	if len(events) != 1 || events[0].RequestID != "req-42" {
+		t.Errorf("Expected event with request ID req-42, got %+v", events)
+	}
+
+	if _, err := client.GetRandomUint8(1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if seen != "" || events[1].RequestID != "" {
+		t.Errorf("Expected no request ID without context, got header %q and event %q", seen, events[1].RequestID)
+	}
+}
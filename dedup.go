@@ -0,0 +1,30 @@
+package qrng
+
+import (
+	"context"
+	"net/url"
+)
+
+// doShared collapses concurrent requests that share an idempotency token and
+// parameters into a single call. The shared call is detached from any one
+// caller's cancellation; each caller still stops waiting when its own ctx is
+// done.
+func (c *QRNGClient) doShared(ctx context.Context, token, apiKey string, length int, dataType string, params url.Values) (*QRNGResponse, error) {
+	key := token + "\x00" + apiKey + "\x00" + params.Encode()
+
+	ch := c.flight.DoChan(key, func() (any, error) {
+		return c.doWithRetry(context.WithoutCancel(ctx), apiKey, length, dataType, params)
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		qr := *res.Val.(*QRNGResponse)
+		qr.Data = append([]int(nil), qr.Data...)
+		return &qr, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
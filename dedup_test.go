@@ -0,0 +1,83 @@
+package qrng_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestIdempotencyTokenDeduplication(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			started <- struct{}{}
+			<-release
+		}
+		fmt.Fprintf(w, `{"type":"uint8","length":1,"data":[%d],"success":true}`, n)
+	}))
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	const callers = 5
+	results := make([][]uint8, callers)
+	var wg sync.WaitGroup
+
+	ctx := qrng.ContextWithIdempotencyToken(context.Background(), "op-1")
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			data, err := client.GetRandomUint8Context(ctx, 1)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+			results[i] = data
+		}(i)
+	}
+
+	// give the remaining callers time to join the in-flight request
+	<-started
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected 1 request, got %d", calls)
+	}
+	for _, r := range results {
+		if fmt.Sprint(r) != fmt.Sprint(results[0]) {
+			t.Errorf("Expected shared result, got %v and %v", results[0], r)
+		}
+	}
+
+	t.Run("untokened requests are independent", func(t *testing.T) {
+		before := atomic.LoadInt32(&calls)
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.GetRandomUint8(1); err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+		if got := atomic.LoadInt32(&calls) - before; got != 3 {
+			t.Errorf("Expected 3 requests, got %d", got)
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxDice caps the number of dice in one RollNotation expression
+const maxDice = 1000
+
+var ErrInvalidDiceNotation = errors.New("invalid dice notation")
+
+var diceNotation = regexp.MustCompile(`^(\d*)[dD](\d+)(?:([+-])(\d+))?$`)
+
+// RollNotation rolls dice written in standard notation such as "3d6+2",
+// "d20" or "4d8-1": an optional die count (default 1), 'd', the number of
+// sides and an optional +/- modifier. Whitespace is ignored. It returns the
+// total including the modifier and the individual rolls, all drawn from one
+// batched fetch. Malformed expressions fail with ErrInvalidDiceNotation.
+func (c *QRNGClient) RollNotation(expr string) (int, []int, error) {
+	compact := strings.Join(strings.Fields(expr), "")
+	m := diceNotation.FindStringSubmatch(compact)
+	if m == nil {
+		return 0, nil, fmt.Errorf("%w: %q", ErrInvalidDiceNotation, expr)
+	}
+
+	count := 1
+	if m[1] != "" {
+		n, err := strconv.Atoi(m[1])
+		if err != nil || n < 1 || n > maxDice {
+			return 0, nil, fmt.Errorf("%w: die count must be between 1 and %d", ErrInvalidDiceNotation, maxDice)
+		}
+		count = n
+	}
+
+	sides, err := strconv.Atoi(m[2])
+	if err != nil || sides < 1 || sides > math.MaxInt32 {
+		return 0, nil, fmt.Errorf("%w: sides must be between 1 and %d", ErrInvalidDiceNotation, math.MaxInt32)
+	}
+
+	modifier := 0
+	if m[3] != "" {
+		modifier, err = strconv.Atoi(m[4])
+		if err != nil || modifier > math.MaxInt32 {
+			return 0, nil, fmt.Errorf("%w: modifier must be at most %d", ErrInvalidDiceNotation, math.MaxInt32)
+		}
+		if m[3] == "-" {
+			modifier = -modifier
+		}
+	}
+
+	rolls, err := c.randomInts(context.Background(), 1, sides, count)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	total := modifier
+	for _, r := range rolls {
+		total += r
+	}
+	return total, rolls, nil
+}
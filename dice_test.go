@@ -0,0 +1,48 @@
+package qrng_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestRollNotation(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":3,"data":[0,3,5],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	tests := []struct {
+		expr  string
+		total int
+		rolls string
+	}{
+		{"3d6+2", 13, "[1 4 6]"},
+		{" 3 D 6 - 1 ", 10, "[1 4 6]"},
+		{"d8", 1, "[1]"},
+		{"2d8", 5, "[1 4]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			total, rolls, err := client.RollNotation(tt.expr)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if total != tt.total || fmt.Sprint(rolls) != tt.rolls {
+				t.Errorf("Expected (%d, %s), got (%d, %v)", tt.total, tt.rolls, total, rolls)
+			}
+		})
+	}
+
+	t.Run("malformed expressions", func(t *testing.T) {
+		for _, expr := range []string{"", "d", "3d", "3x6", "0d6", "2d0", "1d6+", "1d6*2", "1001d6", "-2d6"} {
+			if _, _, err := client.RollNotation(expr); !errors.Is(err, qrng.ErrInvalidDiceNotation) {
+				t.Errorf("%q: expected ErrInvalidDiceNotation, got %v", expr, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,387 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+)
+
+var (
+	ErrNilInverseCDF      = errors.New("inverseCDF must not be nil")
+	ErrInvalidProbability = errors.New("probability must be between 0 and 1")
+)
+
+// GetRandomCustom samples an arbitrary continuous distribution by inverse
+// transform: it draws u uniform in [0,1) and returns inverseCDF(u)
+func (c *QRNGClient) GetRandomCustom(inverseCDF func(u float64) float64) (float64, error) {
+	values, err := c.GetRandomCustomN(1, inverseCDF)
+	if err != nil {
+		return 0, err
+	}
+	return values[0], nil
+}
+
+// GetRandomCustomN returns n samples of inverseCDF(u) from one batched fetch
+func (c *QRNGClient) GetRandomCustomN(n int, inverseCDF func(u float64) float64) ([]float64, error) {
+	if inverseCDF == nil {
+		return nil, ErrNilInverseCDF
+	}
+	if n < 1 {
+		return nil, errors.New("n must be at least 1")
+	}
+
+	values, err := c.uniforms(context.Background(), n)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, u := range values {
+		values[i] = inverseCDF(u)
+	}
+	return values, nil
+}
+
+// GetRandomBinomial returns the number of successes in n independent trials
+// that each succeed with probability p. Every trial compares one quantum
+// uniform against p, and all n uniforms are fetched in a single batch.
+func (c *QRNGClient) GetRandomBinomial(n int, p float64) (int, error) {
+	if n < 0 {
+		return 0, errors.New("n must not be negative")
+	}
+	if !(p >= 0 && p <= 1) {
+		return 0, ErrInvalidProbability
+	}
+	if n == 0 || p == 0 {
+		return 0, nil
+	}
+	if p == 1 {
+		return n, nil
+	}
+
+	values, err := c.uniforms(context.Background(), n)
+	if err != nil {
+		return 0, err
+	}
+
+	successes := 0
+	for _, u := range values {
+		if u < p {
+			successes++
+		}
+	}
+	return successes, nil
+}
+
+// GetRandomMultinomial returns how many of n independent categorical
+// trials fell in each category, where category i has probability probs[i].
+// Probabilities must be non-negative and sum to 1 within 1e-6; the counts
+// always sum to n. One uniform is drawn per trial, all in a single batch,
+// and n of zero needs no fetch.
+func (c *QRNGClient) GetRandomMultinomial(n int, probs []float64) ([]int, error) {
+	if n < 0 {
+		return nil, errors.New("n must not be negative")
+	}
+	cum, err := cumulative(probs)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int, len(probs))
+	if n == 0 {
+		return counts, nil
+	}
+
+	values, err := c.uniforms(context.Background(), n)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range values {
+		counts[categoricalIndex(cum, u)]++
+	}
+	return counts, nil
+}
+
+// GetRandomTriangular returns a sample from the triangular distribution on
+// [min, max] peaking at mode, as used for PERT-style estimates
+func (c *QRNGClient) GetRandomTriangular(min, mode, max float64) (float64, error) {
+	values, err := c.GetRandomTriangularN(1, min, mode, max)
+	if err != nil {
+		return 0, err
+	}
+	return values[0], nil
+}
+
+// GetRandomTriangularN returns n triangular samples from one batched fetch.
+// Each is drawn by inverting the piecewise-quadratic CDF at a quantum uniform.
+func (c *QRNGClient) GetRandomTriangularN(n int, min, mode, max float64) ([]float64, error) {
+	if !(min <= mode && mode <= max && min < max) {
+		return nil, errors.New("triangular parameters must satisfy min <= mode <= max and min < max")
+	}
+
+	width := max - min
+	split := (mode - min) / width
+	return c.GetRandomCustomN(n, func(u float64) float64 {
+		if u < split {
+			return min + math.Sqrt(u*width*(mode-min))
+		}
+		return max - math.Sqrt((1-u)*width*(max-mode))
+	})
+}
+
+// GetRandomLogNormalFloat64 returns exp(mu + sigma*z) for a standard normal
+// z, so the logarithm of the result is normal with mean mu and standard
+// deviation sigma
+func (c *QRNGClient) GetRandomLogNormalFloat64(mu, sigma float64) (float64, error) {
+	values, err := c.GetRandomLogNormalFloat64N(1, mu, sigma)
+	if err != nil {
+		return 0, err
+	}
+	return values[0], nil
+}
+
+// GetRandomLogNormalFloat64N returns n log-normal samples from one batched
+// fetch, drawing the underlying normals with the Box–Muller transform
+func (c *QRNGClient) GetRandomLogNormalFloat64N(n int, mu, sigma float64) ([]float64, error) {
+	if !(sigma >= 0) || math.IsInf(sigma, 1) {
+		return nil, errors.New("sigma must be non-negative and finite")
+	}
+	if n < 1 {
+		return nil, errors.New("n must be at least 1")
+	}
+
+	values, err := c.normals(context.Background(), n)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, z := range values {
+		values[i] = math.Exp(mu + sigma*z)
+	}
+	return values, nil
+}
+
+type datasetConfig struct {
+	exact bool
+}
+
+// DatasetOption configures GetRandomDataset
+type DatasetOption func(*datasetConfig)
+
+// ExactMoments makes GetRandomDataset standardise the sample and then scale
+// it, so its empirical mean and sample standard deviation (n-1 denominator)
+// equal the targets up to rounding. The values are then no longer
+// independent draws, which is fine for teaching and fixtures but not for
+// statistical tests of the generator. It needs n >= 2.
+func ExactMoments() DatasetOption {
+	return func(cfg *datasetConfig) {
+		cfg.exact = true
+	}
+}
+
+// GetRandomDataset returns n normal values with the given mean and standard
+// deviation, drawn from one batched fetch. Without ExactMoments the sample
+// statistics scatter around the targets as for any random sample.
+func (c *QRNGClient) GetRandomDataset(n int, mean, stddev float64, opts ...DatasetOption) ([]float64, error) {
+	var cfg datasetConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if n < 1 || cfg.exact && n < 2 {
+		return nil, errors.New("n must be at least 1, or 2 with ExactMoments")
+	}
+	if math.IsNaN(mean) || math.IsInf(mean, 0) || !(stddev >= 0) || math.IsInf(stddev, 1) {
+		return nil, errors.New("mean must be finite and stddev non-negative and finite")
+	}
+
+	values, err := c.normals(context.Background(), n)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.exact {
+		sampleMean, sampleSD := meanStdDev(values)
+		// a sample of identical values cannot be standardised; redraw it
+		for sampleSD == 0 {
+			if values, err = c.normals(context.Background(), n); err != nil {
+				return nil, err
+			}
+			sampleMean, sampleSD = meanStdDev(values)
+		}
+		for i, z := range values {
+			values[i] = (z - sampleMean) / sampleSD
+		}
+	}
+
+	for i, z := range values {
+		values[i] = mean + stddev*z
+	}
+	return values, nil
+}
+
+// meanStdDev returns the mean and sample standard deviation of values
+func meanStdDev(values []float64) (mean, sd float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	for _, v := range values {
+		sd += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sd / float64(len(values)-1))
+}
+
+// orderStatisticBetaThreshold is the sample size from which
+// GetRandomOrderStatistic samples the Beta distribution instead of sorting
+const orderStatisticBetaThreshold = 32
+
+// GetRandomOrderStatistic returns the k-th smallest of m independent
+// uniforms on [0,1), so k = 1 gives the minimum and k = m the maximum. Below
+// 32 values it draws all m uniforms in one batch and selects the k-th. For
+// larger m it samples the equivalent Beta(k, m-k+1) distribution directly as
+// X/(X+Y) with X ~ Gamma(k) and Y ~ Gamma(m-k+1), each drawn by Marsaglia
+// and Tsang's rejection method, which needs about three uniforms per gamma
+// regardless of m. k must be between 1 and m.
+func (c *QRNGClient) GetRandomOrderStatistic(m, k int) (float64, error) {
+	if m < 1 {
+		return 0, errors.New("m must be at least 1")
+	}
+	if k < 1 || k > m {
+		return 0, errors.New("k must be between 1 and m")
+	}
+
+	if m < orderStatisticBetaThreshold {
+		values, err := c.uniforms(context.Background(), m)
+		if err != nil {
+			return 0, err
+		}
+		slices.Sort(values)
+		return values[k-1], nil
+	}
+
+	s := c.newUniformStream(context.Background(), 16)
+	x, err := s.gamma(float64(k))
+	if err != nil {
+		return 0, err
+	}
+	y, err := s.gamma(float64(m - k + 1))
+	if err != nil {
+		return 0, err
+	}
+	return x / (x + y), nil
+}
+
+// gamma draws from Gamma(shape, 1) for shape >= 1 using G. Marsaglia and
+// W. Tsang, "A simple method for generating gamma variables" (2000). The
+// normal deviate comes from one Box–Muller step.
+func (s *uniformStream) gamma(shape float64) (float64, error) {
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+
+	for {
+		u1, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+		u2, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+		x := math.Sqrt(-2*math.Log(1-u1)) * math.Cos(2*math.Pi*u2)
+
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+
+		u, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v, nil
+		}
+	}
+}
+
+// poissonPTRSThreshold is the mean above which GetRandomPoisson switches from
+// Knuth's multiplication method to transformed rejection
+const poissonPTRSThreshold = 10
+
+// GetRandomPoisson returns a Poisson-distributed count with mean lambda.
+// Small means use Knuth's method, multiplying uniforms until the product
+// drops below e^-lambda, which needs about lambda+1 draws. Larger means use
+// Hörmann's PTRS transformed rejection, which needs about two draws per
+// sample regardless of lambda. Uniforms are fetched in batches.
+func (c *QRNGClient) GetRandomPoisson(lambda float64) (int, error) {
+	if !(lambda > 0) || math.IsInf(lambda, 1) {
+		return 0, errors.New("lambda must be positive and finite")
+	}
+
+	if lambda < poissonPTRSThreshold {
+		return c.poissonKnuth(lambda)
+	}
+	return c.poissonPTRS(lambda)
+}
+
+func (c *QRNGClient) poissonKnuth(lambda float64) (int, error) {
+	s := c.newUniformStream(context.Background(), int(math.Ceil(lambda))+1)
+	limit := math.Exp(-lambda)
+
+	k := 0
+	p := 1.0
+	for {
+		u, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+		p *= u
+		if p <= limit {
+			return k, nil
+		}
+		k++
+	}
+}
+
+// poissonPTRS implements W. Hörmann, "The transformed rejection method for
+// generating Poisson random variables" (1993), valid for lambda >= 10
+func (c *QRNGClient) poissonPTRS(lambda float64) (int, error) {
+	s := c.newUniformStream(context.Background(), 8)
+
+	slam := math.Sqrt(lambda)
+	loglam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+
+	for {
+		u, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+		v, err := s.next()
+		if err != nil {
+			return 0, err
+		}
+
+		u -= 0.5
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+
+		if us >= 0.07 && v <= vr {
+			return int(k), nil
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invalpha)-math.Log(a/(us*us)+b) <= -lambda+k*loglam-lg {
+			return int(k), nil
+		}
+	}
+}
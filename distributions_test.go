@@ -0,0 +1,399 @@
+package qrng_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+// halfBody returns a uint8 response whose 8-byte groups decode to exactly 0.5
+func halfBody(floats int) string {
+	group := "128,0,0,0,0,0,0,0,"
+	return `{"type":"uint8","length":1,"data":[` + strings.TrimSuffix(strings.Repeat(group, floats), ",") + `],"success":true}`
+}
+
+func TestGetRandomCustom(t *testing.T) {
+	t.Run("applies inverse CDF", func(t *testing.T) {
+		server := newStaticServer(t, halfBody(3))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		// exponential distribution with rate 1
+		expInv := func(u float64) float64 { return -math.Log(1 - u) }
+
+		v, err := client.GetRandomCustom(expInv)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if math.Abs(v-math.Ln2) > 1e-12 {
+			t.Errorf("Expected ln 2, got %v", v)
+		}
+
+		values, err := client.GetRandomCustomN(3, expInv)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(values) != 3 {
+			t.Errorf("Expected 3 values, got %d", len(values))
+		}
+	})
+
+	t.Run("nil callback", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomCustom(nil); !errors.Is(err, qrng.ErrNilInverseCDF) {
+			t.Errorf("Expected ErrNilInverseCDF, got %v", err)
+		}
+	})
+}
+
+func TestGetRandomBinomial(t *testing.T) {
+	t.Run("counts draws below p", func(t *testing.T) {
+		// uniforms 0.5, 0.25, 0.75, 0.0
+		server := newStaticServer(t, `{"type":"uint8","length":32,"data":[`+
+			`128,0,0,0,0,0,0,0,64,0,0,0,0,0,0,0,192,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		k, err := client.GetRandomBinomial(4, 0.5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if k != 2 {
+			t.Errorf("Expected 2 successes, got %d", k)
+		}
+	})
+
+	t.Run("degenerate inputs need no fetch", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = "http://127.0.0.1:0"
+
+		for _, tc := range []struct {
+			n    int
+			p    float64
+			want int
+		}{{0, 0.3, 0}, {10, 0, 0}, {10, 1, 10}} {
+			k, err := client.GetRandomBinomial(tc.n, tc.p)
+			if err != nil || k != tc.want {
+				t.Errorf("Binomial(%d, %v): expected %d, got %d (%v)", tc.n, tc.p, tc.want, k, err)
+			}
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomBinomial(-1, 0.5); err == nil {
+			t.Error("Expected error for negative n")
+		}
+		for _, p := range []float64{-0.1, 1.1, math.NaN()} {
+			if _, err := client.GetRandomBinomial(3, p); !errors.Is(err, qrng.ErrInvalidProbability) {
+				t.Errorf("Expected ErrInvalidProbability for %v, got %v", p, err)
+			}
+		}
+	})
+}
+
+func TestGetRandomMultinomial(t *testing.T) {
+	t.Run("tallies categories", func(t *testing.T) {
+		server := uniformSequenceServer(t, 0.4, 0.25, 0.75, 0.0, 0.9)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		counts, err := client.GetRandomMultinomial(5, []float64{0.3, 0.2, 0.5})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(counts) != "[2 1 2]" {
+			t.Errorf("Expected [2 1 2], got %v", counts)
+		}
+	})
+
+	t.Run("zero trials need no fetch", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = "http://127.0.0.1:0"
+
+		counts, err := client.GetRandomMultinomial(0, []float64{0.5, 0.5})
+		if err != nil || fmt.Sprint(counts) != "[0 0]" {
+			t.Errorf("Expected [0 0], got %v (%v)", counts, err)
+		}
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomMultinomial(-1, []float64{1}); err == nil {
+			t.Error("Expected error for negative n")
+		}
+		for _, probs := range [][]float64{nil, {0.5, 0.4}, {1.5, -0.5}} {
+			if _, err := client.GetRandomMultinomial(3, probs); !errors.Is(err, qrng.ErrInvalidDistribution) {
+				t.Errorf("%v: expected ErrInvalidDistribution, got %v", probs, err)
+			}
+		}
+	})
+}
+
+// uniformSequenceServer serves the given values in [0,1), cycling, encoded
+// as the 8-byte groups the client decodes into uniforms
+func uniformSequenceServer(t *testing.T, values ...float64) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	next := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		length, _ := strconv.Atoi(r.URL.Query().Get("length"))
+		mu.Lock()
+		data := make([]string, 0, length)
+		for len(data) < length {
+			u := values[next%len(values)]
+			next++
+			var b [8]byte
+			binary.BigEndian.PutUint64(b[:], uint64(u*(1<<53))<<11)
+			for _, x := range b {
+				data = append(data, strconv.Itoa(int(x)))
+			}
+		}
+		mu.Unlock()
+		fmt.Fprintf(w, `{"type":"uint8","length":%d,"data":[%s],"success":true}`, length, strings.Join(data, ","))
+	}))
+}
+
+func TestGetRandomPoisson(t *testing.T) {
+	t.Run("knuth", func(t *testing.T) {
+		// e^-1 ~ 0.368: 0.9 -> 0.9, 0.8 -> 0.72, 0.5 -> 0.36 stops at k=2
+		server := uniformSequenceServer(t, 0.9, 0.8, 0.5)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		k, err := client.GetRandomPoisson(1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if k != 2 {
+			t.Errorf("Expected 2, got %d", k)
+		}
+	})
+
+	t.Run("transformed rejection", func(t *testing.T) {
+		// u=0.5 centers the draw at floor(lambda + 0.43); v=0 is always accepted
+		server := uniformSequenceServer(t, 0.5, 0)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		k, err := client.GetRandomPoisson(100)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if k != 100 {
+			t.Errorf("Expected 100, got %d", k)
+		}
+	})
+
+	t.Run("invalid lambda", func(t *testing.T) {
+		client := qrng.NewClient()
+		for _, l := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+			if _, err := client.GetRandomPoisson(l); err == nil {
+				t.Errorf("Expected error for lambda %v", l)
+			}
+		}
+	})
+}
+
+func TestGetRandomOrderStatistic(t *testing.T) {
+	t.Run("selection", func(t *testing.T) {
+		server := uniformSequenceServer(t, 0.75, 0.25, 0.5)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		v, err := client.GetRandomOrderStatistic(3, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if v != 0.5 {
+			t.Errorf("Expected 0.5, got %v", v)
+		}
+	})
+
+	t.Run("beta", func(t *testing.T) {
+		// All-zero uniforms give a zero normal, which is always accepted
+		// and makes each Gamma(a) draw exactly a - 1/3
+		server := uniformSequenceServer(t, 0)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		v, err := client.GetRandomOrderStatistic(100, 50)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		want := (50 - 1.0/3) / (100 + 1.0/3)
+		if math.Abs(v-want) > 1e-12 {
+			t.Errorf("Expected %v, got %v", want, v)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		client := qrng.NewClient()
+		for _, mk := range [][2]int{{0, 1}, {3, 0}, {3, 4}} {
+			if _, err := client.GetRandomOrderStatistic(mk[0], mk[1]); err == nil {
+				t.Errorf("Expected error for m=%d k=%d", mk[0], mk[1])
+			}
+		}
+	})
+}
+
+func TestGetRandomTriangular(t *testing.T) {
+	t.Run("inverts both sides of the mode", func(t *testing.T) {
+		server := uniformSequenceServer(t, 0.0625, 0.25, 0.75)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		values, err := client.GetRandomTriangularN(3, 0, 1, 4)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := []float64{0.5, 1, 4 - math.Sqrt(3)}
+		for i, want := range expected {
+			if math.Abs(values[i]-want) > 1e-9 {
+				t.Errorf("Sample %d: expected %v, got %v", i, want, values[i])
+			}
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		client := qrng.NewClient()
+		for _, p := range [][3]float64{{1, 0, 2}, {0, 3, 2}, {1, 1, 1}, {0, math.NaN(), 1}} {
+			if _, err := client.GetRandomTriangular(p[0], p[1], p[2]); err == nil {
+				t.Errorf("Expected error for %v", p)
+			}
+		}
+	})
+}
+
+func TestGetRandomLogNormalFloat64(t *testing.T) {
+	t.Run("transforms Box-Muller normals", func(t *testing.T) {
+		// u1 = 1 - e^-2 gives radius 2; u2 = 0 and 0.25 point along cos and sin
+		server := uniformSequenceServer(t, 1-math.Exp(-2), 0, 1-math.Exp(-2), 0.25)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		values, err := client.GetRandomLogNormalFloat64N(3, 1, 0.5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		// z = 2, 0 from the first pair and 0 from the second, truncated to n = 3
+		expected := []float64{math.Exp(2), math.E, math.E}
+		for i, want := range expected {
+			if math.Abs(values[i]-want) > 1e-9 {
+				t.Errorf("Sample %d: expected %v, got %v", i, want, values[i])
+			}
+		}
+	})
+
+	t.Run("zero sigma", func(t *testing.T) {
+		server := uniformSequenceServer(t, 0.3, 0.7)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		v, err := client.GetRandomLogNormalFloat64(2, 0)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if math.Abs(v-math.Exp(2)) > 1e-12 {
+			t.Errorf("Expected e^2, got %v", v)
+		}
+	})
+
+	t.Run("invalid sigma", func(t *testing.T) {
+		client := qrng.NewClient()
+		for _, sigma := range []float64{-1, math.NaN(), math.Inf(1)} {
+			if _, err := client.GetRandomLogNormalFloat64(0, sigma); err == nil {
+				t.Errorf("Expected error for sigma %v", sigma)
+			}
+		}
+	})
+}
+
+func TestGetRandomDataset(t *testing.T) {
+	t.Run("scales normals", func(t *testing.T) {
+		// normals 2, 0 from radius 2 at angle 0
+		server := uniformSequenceServer(t, 1-math.Exp(-2), 0)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		values, err := client.GetRandomDataset(2, 10, 3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if math.Abs(values[0]-16) > 1e-9 || math.Abs(values[1]-10) > 1e-9 {
+			t.Errorf("Expected [16 10], got %v", values)
+		}
+	})
+
+	t.Run("exact moments", func(t *testing.T) {
+		server := uniformSequenceServer(t, 0.11, 0.23, 0.58, 0.91, 0.37, 0.64)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		values, err := client.GetRandomDataset(5, 50, 4, qrng.ExactMoments())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		mean := 0.0
+		for _, v := range values {
+			mean += v
+		}
+		mean /= float64(len(values))
+		ss := 0.0
+		for _, v := range values {
+			ss += (v - mean) * (v - mean)
+		}
+		sd := math.Sqrt(ss / float64(len(values)-1))
+
+		if math.Abs(mean-50) > 1e-9 || math.Abs(sd-4) > 1e-9 {
+			t.Errorf("Expected mean 50 and sd 4, got %v and %v", mean, sd)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomDataset(0, 0, 1); err == nil {
+			t.Error("Expected error for n 0")
+		}
+		if _, err := client.GetRandomDataset(1, 0, 1, qrng.ExactMoments()); err == nil {
+			t.Error("Expected error for n 1 with ExactMoments")
+		}
+		if _, err := client.GetRandomDataset(3, 0, -1); err == nil {
+			t.Error("Expected error for negative stddev")
+		}
+	})
+}
@@ -0,0 +1,47 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// minDuplicateBits is the smallest batch cross-checked by
+// WithDuplicateDetection; genuine batches of 64 bits or more collide with
+// probability at most 2^-64, while shorter ones collide too often to treat
+// a match as evidence of tampering
+const minDuplicateBits = 64
+
+var ErrSuspiciousDuplicate = errors.New("two independent fetches returned identical data")
+
+// WithDuplicateDetection makes every API request of at least 64 bits issue a
+// second, independent request and fail with ErrSuspiciousDuplicate if both
+// return the same data, which genuine quantum output practically never does.
+// This detects a caching or tampering proxy replaying responses. It doubles
+// quota usage; smaller requests are not checked. The second batch is only a
+// witness and is discarded. It is not written by WithRecorder, so a
+// NewReplayClient serving the log must not enable this option.
+func WithDuplicateDetection() Option {
+	return func(c *QRNGClient) {
+		c.duplicateDetection = true
+	}
+}
+
+// crossCheck fetches an independent witness batch and compares it with qr
+func (c *QRNGClient) crossCheck(ctx context.Context, apiKey string, length int, dataType string, params url.Values, qr *QRNGResponse) error {
+	if length*valueBits(dataType, params) < minDuplicateBits {
+		return nil
+	}
+
+	ctx = context.WithValue(ctx, witnessContextKey, true)
+	witness, err := c.doWithRetry(ctx, apiKey, length, dataType, params)
+	if err != nil {
+		return fmt.Errorf("duplicate check: %w", err)
+	}
+	if slices.Equal(qr.Data, witness.Data) {
+		return ErrSuspiciousDuplicate
+	}
+	return nil
+}
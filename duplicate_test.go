@@ -0,0 +1,94 @@
+package qrng_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestWithDuplicateDetection(t *testing.T) {
+	t.Run("identical responses rejected", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithDuplicateDetection())
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(8); !errors.Is(err, qrng.ErrSuspiciousDuplicate) {
+			t.Errorf("Expected ErrSuspiciousDuplicate, got %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 requests, got %d", calls)
+		}
+	})
+
+	t.Run("distinct responses accepted", func(t *testing.T) {
+		var calls int32
+		server := sequenceServer(t, &calls, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithDuplicateDetection())
+		client.APIEndpoint = server.URL
+
+		data, err := client.GetRandomUint8(8)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if data[0] != 1 || data[7] != 8 {
+			t.Errorf("Expected the first batch to be returned, got %v", data)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 requests, got %d", calls)
+		}
+	})
+
+	t.Run("witness not recorded", func(t *testing.T) {
+		var calls int32
+		server := sequenceServer(t, &calls, 1, 2, 3, 4, 5, 6, 7, 8, 9)
+		defer server.Close()
+
+		var log bytes.Buffer
+		client := qrng.NewClient(qrng.WithDuplicateDetection(), qrng.WithRecorder(&log))
+		client.APIEndpoint = server.URL
+
+		data, err := client.GetRandomUint8(8)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		replay, err := qrng.NewReplayClient(&log)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		got, err := replay.GetRandomUint8(8)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(data) {
+			t.Errorf("Expected %v replayed, got %v", data, got)
+		}
+		if _, err := replay.GetRandomUint8(8); !errors.Is(err, qrng.ErrReplayExhausted) {
+			t.Errorf("Expected only one recorded batch, got %v", err)
+		}
+	})
+
+	t.Run("short requests not checked", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 0, http.StatusOK)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithDuplicateDetection())
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if *calls != 1 {
+			t.Errorf("Expected 1 request, got %d", *calls)
+		}
+	})
+}
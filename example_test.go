@@ -0,0 +1,79 @@
+package qrng_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+// Bound a request with a deadline and tell cancellation apart from API errors.
+func ExampleQRNGClient_GetRandomUint8Context() {
+	client := qrng.NewClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	data, err := client.GetRandomUint8Context(ctx, 16)
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		log.Println("QRNG did not answer within 2s, falling back")
+	case err != nil:
+		log.Printf("QRNG error: %v", err)
+	default:
+		fmt.Println("Random bytes:", data)
+	}
+}
+
+// A buffered client serves many small draws from one batched request.
+func ExampleQRNGClient_Warmup() {
+	client := qrng.NewClient()
+	client.BufferSize = 256
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := client.Warmup(ctx, 256); err != nil {
+		log.Printf("warmup failed: %v", err)
+		return
+	}
+
+	for i := 0; i < 4; i++ {
+		// served from the pool, no network round-trip
+		b, err := client.GetRandomUint8(1)
+		if err != nil {
+			log.Printf("QRNG error: %v", err)
+			return
+		}
+		fmt.Println("Byte:", b[0])
+	}
+}
+
+// The client is safe for concurrent use.
+func ExampleQRNGClient_GetRandomNumber_concurrent() {
+	client := qrng.NewClient()
+
+	var wg sync.WaitGroup
+	results := make([]int, 3)
+	errs := make([]error, 3)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = client.GetRandomNumber(1, 6)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			log.Printf("roll %d failed: %v", i, err)
+			continue
+		}
+		fmt.Printf("Roll %d: %d\n", i, results[i])
+	}
+}
@@ -0,0 +1,87 @@
+// Package fakedata generates plausible but clearly fake user data for tests,
+// with every choice drawn uniformly from quantum entropy.
+//
+// Emails use the reserved example.com/.org/.net domains (RFC 2606) and phone
+// numbers use the 555-0100 to 555-0199 range set aside for fiction, so
+// generated values never belong to real people.
+package fakedata
+
+import (
+	"strconv"
+	"strings"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+var (
+	adjectives = []string{
+		"amber", "brave", "calm", "dusty", "eager", "fuzzy", "gentle", "hasty",
+		"icy", "jolly", "keen", "lucky", "mellow", "nimble", "odd", "plucky",
+		"quiet", "rapid", "shy", "tidy", "upbeat", "vivid", "witty", "zesty",
+	}
+	nouns = []string{
+		"badger", "comet", "dingo", "falcon", "gecko", "heron", "ibis", "koala",
+		"lemur", "marmot", "newt", "otter", "panda", "quokka", "raven", "squid",
+		"tapir", "urchin", "vole", "walrus", "yak", "zebra", "photon", "qubit",
+	}
+	domains = []string{"example.com", "example.org", "example.net"}
+)
+
+// RandomUsername returns a name such as "plucky_otter42"
+func RandomUsername(client *qrng.QRNGClient) (string, error) {
+	pick, err := client.GetRandomNumber(0, len(adjectives)*len(nouns)*100-1)
+	if err != nil {
+		return "", err
+	}
+	return username(pick), nil
+}
+
+func username(n int) string {
+	digits := n % 100
+	n /= 100
+	noun := nouns[n%len(nouns)]
+	adjective := adjectives[n/len(nouns)]
+	return adjective + "_" + noun + strconv.Itoa(digits)
+}
+
+// RandomEmail returns an address such as "keen_walrus07@example.org" on a
+// reserved example domain
+func RandomEmail(client *qrng.QRNGClient) (string, error) {
+	pick, err := client.GetRandomNumber(0, len(adjectives)*len(nouns)*100*len(domains)-1)
+	if err != nil {
+		return "", err
+	}
+	domain := domains[pick%len(domains)]
+	return username(pick/len(domains)) + "@" + domain, nil
+}
+
+// RandomPhoneDigits returns ten NANP digits: a plausible area code followed
+// by 555-01xx, the exchange reserved for fictional numbers. Area codes of
+// the N11 form, such as 211 and 911, are never produced.
+func RandomPhoneDigits(client *qrng.QRNGClient) (string, error) {
+	// area code NXX: N in 2-9, then one of the 99 two-digit suffixes other
+	// than 11; line 00-99
+	n, err := client.GetRandomNumber(0, 8*99*100-1)
+	if err != nil {
+		return "", err
+	}
+
+	suffix := n / 100 % 99
+	if suffix >= 11 {
+		suffix++
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(n/(99*100) + 2))
+	sb.WriteString(pad2(suffix))
+	sb.WriteString("55501")
+	sb.WriteString(pad2(n % 100))
+	return sb.String(), nil
+}
+
+func pad2(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
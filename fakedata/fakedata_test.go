@@ -0,0 +1,76 @@
+package fakedata_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+	"github.com/albertnieto/anu-qrng-go/fakedata"
+)
+
+func newClient(t *testing.T) *qrng.QRNGClient {
+	t.Helper()
+	next := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		length, _ := strconv.Atoi(r.URL.Query().Get("length"))
+		data := make([]string, length)
+		for i := range data {
+			data[i] = strconv.Itoa((next*37 + 11) % 256)
+			next++
+		}
+		fmt.Fprintf(w, `{"type":"uint8","length":%d,"data":[%s],"success":true}`, length, strings.Join(data, ","))
+	}))
+	t.Cleanup(server.Close)
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+	return client
+}
+
+func TestRandomUsername(t *testing.T) {
+	name, err := fakedata.RandomUsername(newClient(t))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !regexp.MustCompile(`^[a-z]+_[a-z]+\d{1,2}$`).MatchString(name) {
+		t.Errorf("Unexpected username %q", name)
+	}
+}
+
+func TestRandomEmail(t *testing.T) {
+	email, err := fakedata.RandomEmail(newClient(t))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !regexp.MustCompile(`^[a-z]+_[a-z]+\d{1,2}@example\.(com|org|net)$`).MatchString(email) {
+		t.Errorf("Unexpected email %q", email)
+	}
+}
+
+func TestRandomPhoneDigits(t *testing.T) {
+	phone, err := fakedata.RandomPhoneDigits(newClient(t))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !regexp.MustCompile(`^[2-9]\d{2}55501\d{2}$`).MatchString(phone) {
+		t.Errorf("Unexpected phone digits %q", phone)
+	}
+}
+
+func TestRandomPhoneDigitsSkipsN11(t *testing.T) {
+	client := newClient(t)
+	for i := 0; i < 200; i++ {
+		phone, err := fakedata.RandomPhoneDigits(client)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if phone[1:3] == "11" {
+			t.Fatalf("Unexpected N11 area code in %q", phone)
+		}
+	}
+}
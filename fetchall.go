@@ -0,0 +1,134 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const defaultFetchConcurrency = 4
+
+// RequestKind selects what a Request fetches
+type RequestKind int
+
+const (
+	// RequestBits fetches Count bits
+	RequestBits RequestKind = iota
+	// RequestBytes fetches Count bytes
+	RequestBytes
+	// RequestNumbers fetches Count integers uniform in [Min, Max]
+	RequestNumbers
+)
+
+// Request describes one operation for FetchAll
+type Request struct {
+	Kind     RequestKind
+	Count    int
+	Min, Max int
+}
+
+// Result holds the outcome of the Request at the same index. Only the field
+// matching the request kind is set.
+type Result struct {
+	Bits    []int
+	Bytes   []byte
+	Numbers []int
+	Err     error
+}
+
+type fetchConfig struct {
+	concurrency     int
+	continueOnError bool
+}
+
+// FetchOption configures FetchAll
+type FetchOption func(*fetchConfig)
+
+// FetchConcurrency limits how many requests FetchAll runs at once (default 4)
+func FetchConcurrency(n int) FetchOption {
+	return func(cfg *fetchConfig) {
+		if n > 0 {
+			cfg.concurrency = n
+		}
+	}
+}
+
+// ContinueOnError makes FetchAll run every request even after a failure.
+// Each Result carries its own Err and FetchAll returns all failures joined.
+func ContinueOnError() FetchOption {
+	return func(cfg *fetchConfig) {
+		cfg.continueOnError = true
+	}
+}
+
+// FetchAll runs reqs concurrently, bounded by FetchConcurrency, and returns
+// their results in request order. By default the first failure cancels the
+// remaining requests and is returned; see ContinueOnError.
+func (c *QRNGClient) FetchAll(ctx context.Context, reqs []Request, opts ...FetchOption) ([]Result, error) {
+	cfg := fetchConfig{concurrency: defaultFetchConcurrency}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ctx, cancel := withTimeout(ctx, c.Timeouts.Bulk)
+	defer cancel()
+
+	results := make([]Result, len(reqs))
+
+	var g *errgroup.Group
+	gctx := ctx
+	if cfg.continueOnError {
+		g = &errgroup.Group{}
+	} else {
+		g, gctx = errgroup.WithContext(ctx)
+	}
+	g.SetLimit(cfg.concurrency)
+
+	for i, req := range reqs {
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				results[i].Err = err
+				return err
+			}
+			results[i] = c.fetchOne(gctx, req)
+			if cfg.continueOnError {
+				return nil
+			}
+			return results[i].Err
+		})
+	}
+
+	err := g.Wait()
+	if cfg.continueOnError {
+		errs := make([]error, 0, len(results))
+		for _, r := range results {
+			errs = append(errs, r.Err)
+		}
+		return results, errors.Join(errs...)
+	}
+	return results, err
+}
+
+func (c *QRNGClient) fetchOne(ctx context.Context, req Request) Result {
+	if req.Count < 1 {
+		return Result{Err: errors.New("request count must be at least 1")}
+	}
+
+	switch req.Kind {
+	case RequestBits:
+		data, err := c.readBytes(ctx, (req.Count+7)/8)
+		if err != nil {
+			return Result{Err: err}
+		}
+		return Result{Bits: extractBits(data, req.Count)}
+	case RequestBytes:
+		data, err := c.readBytes(ctx, req.Count)
+		return Result{Bytes: data, Err: err}
+	case RequestNumbers:
+		nums, err := c.randomInts(ctx, req.Min, req.Max, req.Count)
+		return Result{Numbers: nums, Err: err}
+	}
+	return Result{Err: fmt.Errorf("unknown request kind %d", req.Kind)}
+}
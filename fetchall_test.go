@@ -0,0 +1,87 @@
+package qrng_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestFetchAll(t *testing.T) {
+	var calls int32
+	server := newCountingServer(t, &calls)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	t.Run("heterogeneous requests in order", func(t *testing.T) {
+		results, err := client.FetchAll(context.Background(), []qrng.Request{
+			{Kind: qrng.RequestBits, Count: 12},
+			{Kind: qrng.RequestBytes, Count: 5},
+			{Kind: qrng.RequestNumbers, Count: 3, Min: 0, Max: 255},
+		}, qrng.FetchConcurrency(2))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(results[0].Bits) != 12 || len(results[1].Bytes) != 5 || len(results[2].Numbers) != 3 {
+			t.Errorf("Unexpected results %+v", results)
+		}
+	})
+
+	t.Run("first error returned", func(t *testing.T) {
+		_, err := client.FetchAll(context.Background(), []qrng.Request{
+			{Kind: qrng.RequestBytes, Count: 1},
+			{Kind: qrng.RequestNumbers, Count: 1, Min: 5, Max: 1},
+		})
+		if err != qrng.ErrInvalidRange {
+			t.Errorf("Expected ErrInvalidRange, got %v", err)
+		}
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		results, err := client.FetchAll(context.Background(), []qrng.Request{
+			{Kind: qrng.RequestNumbers, Count: 1, Min: 5, Max: 1},
+			{Kind: qrng.RequestBytes, Count: 4},
+		}, qrng.ContinueOnError(), qrng.FetchConcurrency(1))
+		if err == nil {
+			t.Fatal("Expected joined error")
+		}
+		if results[0].Err == nil || results[1].Err != nil || len(results[1].Bytes) != 4 {
+			t.Errorf("Unexpected results %+v", results)
+		}
+	})
+
+	t.Run("concurrency limit", func(t *testing.T) {
+		var inFlight, peak int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			defer atomic.AddInt32(&inFlight, -1)
+			w.Write([]byte(`{"type":"uint8","length":1,"data":[1],"success":true}`))
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		reqs := make([]qrng.Request, 10)
+		for i := range reqs {
+			reqs[i] = qrng.Request{Kind: qrng.RequestBytes, Count: 1}
+		}
+		if _, err := client.FetchAll(context.Background(), reqs, qrng.FetchConcurrency(2)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if peak > 2 {
+			t.Errorf("Expected at most 2 concurrent requests, saw %d", peak)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package qrng
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const bytesPerFloat64 = 8
+
+var ErrInvalidDimensions = errors.New("dimensions must be positive")
+
+// uniforms returns n floats uniform in [0,1), each built from the top 53 bits
+// of 8 quantum bytes. All bytes are fetched up front in batched requests.
+func (c *QRNGClient) uniforms(ctx context.Context, n int) ([]float64, error) {
+	if n > math.MaxInt/bytesPerFloat64 {
+		return nil, ErrRangeTooLarge
+	}
+
+	data, err := c.readBytes(ctx, n*bytesPerFloat64)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]float64, n)
+	for i := range result {
+		result[i] = bytesToFloat64(data[i*bytesPerFloat64:])
+	}
+	return result, nil
+}
+
+// normals returns n standard normal values using the Box–Muller transform,
+// which turns each pair of uniforms into two independent normals. The
+// uniforms for all pairs are fetched in one batch.
+func (c *QRNGClient) normals(ctx context.Context, n int) ([]float64, error) {
+	u, err := c.uniforms(ctx, (n+1)/2*2)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]float64, 0, len(u))
+	for i := 0; i < len(u); i += 2 {
+		// 1-u lies in (0,1], keeping the logarithm finite
+		r := math.Sqrt(-2 * math.Log(1-u[i]))
+		sin, cos := math.Sincos(2 * math.Pi * u[i+1])
+		result = append(result, r*cos, r*sin)
+	}
+	return result[:n], nil
+}
+
+// uniformStream hands out quantum uniforms one at a time for rejection
+// samplers whose draw count is not known up front, fetching batch values per
+// request
+type uniformStream struct {
+	c     *QRNGClient
+	ctx   context.Context
+	batch int
+	buf   []float64
+}
+
+func (c *QRNGClient) newUniformStream(ctx context.Context, batch int) *uniformStream {
+	return &uniformStream{c: c, ctx: ctx, batch: max(min(batch, c.maxLength()/bytesPerFloat64), 1)}
+}
+
+func (s *uniformStream) next() (float64, error) {
+	if len(s.buf) == 0 {
+		values, err := s.c.uniforms(s.ctx, s.batch)
+		if err != nil {
+			return 0, err
+		}
+		s.buf = values
+	}
+
+	u := s.buf[0]
+	s.buf = s.buf[1:]
+	return u, nil
+}
+
+func bytesToFloat64(b []byte) float64 {
+	return float64(binary.BigEndian.Uint64(b)>>11) / (1 << 53)
+}
+
+// GetRandomFloat64Matrix returns a rows x cols matrix of floats uniform in
+// [0,1), filled row-major from a single batched fetch
+func (c *QRNGClient) GetRandomFloat64Matrix(rows, cols int) ([][]float64, error) {
+	if rows < 1 || cols < 1 {
+		return nil, ErrInvalidDimensions
+	}
+	if rows > math.MaxInt/cols {
+		return nil, ErrRangeTooLarge
+	}
+
+	values, err := c.uniforms(context.Background(), rows*cols)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := make([][]float64, rows)
+	for i := range matrix {
+		matrix[i] = values[i*cols : (i+1)*cols : (i+1)*cols]
+	}
+	return matrix, nil
+}
+
+// GetRandomFloat64Rounded draws a float uniform in [min, max) and rounds it
+// to decimals places, for fixtures and golden files that should not carry
+// noisy trailing digits. Rounding can land on max itself; results that would
+// round outside the range are clamped to min or max. Because values are
+// binary floats, 0.1-style results are the nearest float64, as with any
+// decimal literal.
+func (c *QRNGClient) GetRandomFloat64Rounded(min, max float64, decimals int) (float64, error) {
+	if decimals < 0 {
+		return 0, errors.New("decimals must not be negative")
+	}
+	if !(min < max) || math.IsInf(min, 0) || math.IsInf(max, 0) {
+		return 0, errors.New("range must be finite with min < max")
+	}
+
+	u, err := c.uniforms(context.Background(), 1)
+	if err != nil {
+		return 0, err
+	}
+
+	x := min + u[0]*(max-min)
+	scale := math.Pow10(decimals)
+	if r := math.Round(x*scale) / scale; !math.IsInf(r, 0) && !math.IsNaN(r) {
+		x = r
+	}
+	return math.Min(math.Max(x, min), max), nil
+}
@@ -0,0 +1,92 @@
+package qrng_test
+
+import (
+	"errors"
+	"math"
+	"sync/atomic"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestGetRandomFloat64Matrix(t *testing.T) {
+	t.Run("row-major fill", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		m, err := client.GetRandomFloat64Matrix(10, 20)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(m) != 10 || len(m[0]) != 20 {
+			t.Fatalf("Unexpected shape %dx%d", len(m), len(m[0]))
+		}
+		for _, row := range m {
+			for _, v := range row {
+				if v < 0 || v >= 1 {
+					t.Errorf("Value %v out of [0,1)", v)
+				}
+			}
+		}
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Errorf("Expected 2 requests for 1600 bytes, got %d", n)
+		}
+	})
+
+	t.Run("invalid dimensions", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomFloat64Matrix(0, 3); !errors.Is(err, qrng.ErrInvalidDimensions) {
+			t.Errorf("Expected ErrInvalidDimensions, got %v", err)
+		}
+		if _, err := client.GetRandomFloat64Matrix(math.MaxInt, 2); !errors.Is(err, qrng.ErrRangeTooLarge) {
+			t.Errorf("Expected ErrRangeTooLarge, got %v", err)
+		}
+	})
+}
+
+func TestGetRandomFloat64Rounded(t *testing.T) {
+	tests := []struct {
+		name     string
+		u        float64
+		min, max float64
+		decimals int
+		expected float64
+	}{
+		{"two places", 0.123456, 0, 10, 2, 1.23},
+		{"no places", 0.5, -3, 3, 0, 0},
+		{"clamped to min", 0, 0.12, 0.19, 1, 0.12},
+		{"may reach max", 0.99, 0, 1, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := uniformSequenceServer(t, tt.u)
+			defer server.Close()
+
+			client := qrng.NewClient()
+			client.APIEndpoint = server.URL
+
+			v, err := client.GetRandomFloat64Rounded(tt.min, tt.max, tt.decimals)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if v != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, v)
+			}
+		})
+	}
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomFloat64Rounded(0, 1, -1); err == nil {
+			t.Error("Expected error for negative decimals")
+		}
+		if _, err := client.GetRandomFloat64Rounded(1, 1, 2); err == nil {
+			t.Error("Expected error for empty range")
+		}
+	})
+}
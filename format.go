@@ -0,0 +1,134 @@
+package qrng
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// GetRandomBitString returns numBits random bits as a string of '0' and '1' characters
+func (c *QRNGClient) GetRandomBitString(numBits int) (string, error) {
+	bits, err := c.GetRandomBits(numBits)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(bits))
+	for _, b := range bits {
+		sb.WriteByte('0' + byte(b))
+	}
+	return sb.String(), nil
+}
+
+// GetRandomDecimalString returns a string of numDigits decimal digits, each
+// drawn uniformly from 0-9 by rejection sampling. Entropy for all digits is
+// fetched in one batch; only rejected draws trigger another fetch.
+func (c *QRNGClient) GetRandomDecimalString(numDigits int) (string, error) {
+	if numDigits < 1 {
+		return "", errors.New("numDigits must be at least 1")
+	}
+
+	digits, err := c.randomInts(context.Background(), 0, 9, numDigits)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.Grow(numDigits)
+	for _, d := range digits {
+		sb.WriteByte('0' + byte(d))
+	}
+	return sb.String(), nil
+}
+
+// GetRandomBase64 returns numBytes random bytes encoded as standard padded base64
+func (c *QRNGClient) GetRandomBase64(numBytes int) (string, error) {
+	data, err := c.GetRandomBytes(numBytes)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// GetRandomBase64URL returns numBytes random bytes encoded as unpadded URL-safe base64
+func (c *QRNGClient) GetRandomBase64URL(numBytes int) (string, error) {
+	data, err := c.GetRandomBytes(numBytes)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// GetRandomBase62 returns a string of length characters from [0-9A-Za-z],
+// suited to referral codes and short links. Each character is an unbiased
+// rejection-sampled draw; entropy for all of them is fetched in one batch.
+func (c *QRNGClient) GetRandomBase62(length int) (string, error) {
+	if length < 1 {
+		return "", errors.New("length must be at least 1")
+	}
+
+	idx, err := c.randomInts(context.Background(), 0, len(base62Alphabet)-1, length)
+	if err != nil {
+		return "", err
+	}
+
+	code := make([]byte, length)
+	for i, k := range idx {
+		code[i] = base62Alphabet[k]
+	}
+	return string(code), nil
+}
+
+// GetRandomDecimal returns a fixed-point decimal string for an integer drawn
+// uniformly from [min, max], with the last scale digits after the decimal
+// point. For example min 10000, max 99999 and scale 2 yield amounts from
+// "100.00" to "999.99". Building the string from the integer avoids float
+// rounding for currency values. On platforms where int is 32 bits, bounds
+// outside its range fail with ErrRangeTooLarge.
+func (c *QRNGClient) GetRandomDecimal(min, max int64, scale int) (string, error) {
+	if scale < 0 {
+		return "", errors.New("scale must not be negative")
+	}
+	if !fitsInt(min) || !fitsInt(max) {
+		return "", ErrRangeTooLarge
+	}
+
+	values, err := c.randomInts(context.Background(), int(min), int(max), 1)
+	if err != nil {
+		return "", err
+	}
+	return formatFixed(int64(values[0]), scale), nil
+}
+
+// formatFixed renders v / 10^scale without going through floating point
+func formatFixed(v int64, scale int) string {
+	neg := v < 0
+	abs := uint64(v)
+	if neg {
+		abs = -abs
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	var sb strings.Builder
+	if neg {
+		sb.WriteByte('-')
+	}
+	if scale == 0 {
+		sb.WriteString(digits)
+		return sb.String()
+	}
+	point := len(digits) - scale
+	sb.WriteString(digits[:point])
+	sb.WriteByte('.')
+	sb.WriteString(digits[point:])
+	return sb.String()
+}
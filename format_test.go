@@ -0,0 +1,178 @@
+package qrng_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func newStaticServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, body)
+	}))
+}
+
+func TestGetRandomBitString(t *testing.T) {
+	t.Run("successful response", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":1,"data":[165],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		s, err := client.GetRandomBitString(6)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if s != "101001" {
+			t.Errorf("Expected 101001, got %s", s)
+		}
+	})
+}
+
+func TestGetRandomDecimalString(t *testing.T) {
+	t.Run("uniform digits with rejection", func(t *testing.T) {
+		// 12 masks to 12 and is redrawn; 25 masks to 9
+		var calls int32
+		server := sequenceServer(t, &calls, 12, 3, 25, 7)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		s, err := client.GetRandomDecimalString(3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if s != "397" {
+			t.Errorf("Expected 397, got %s", s)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 requests, got %d", calls)
+		}
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		if _, err := qrng.NewClient().GetRandomDecimalString(0); err == nil {
+			t.Error("Expected error for zero digits")
+		}
+	})
+}
+
+func TestGetRandomBase64(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":4,"data":[251,255,191,0],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	t.Run("standard encoding", func(t *testing.T) {
+		s, err := client.GetRandomBase64(4)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if s != "+/+/AA==" {
+			t.Errorf("Expected +/+/AA==, got %s", s)
+		}
+	})
+
+	t.Run("url encoding", func(t *testing.T) {
+		s, err := client.GetRandomBase64URL(4)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if s != "-_-_AA" {
+			t.Errorf("Expected -_-_AA, got %s", s)
+		}
+	})
+
+	t.Run("invalid numBytes", func(t *testing.T) {
+		if _, err := client.GetRandomBase64(0); err == nil {
+			t.Error("Expected error for invalid numBytes")
+		}
+	})
+}
+
+func TestGetRandomDecimal(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max int64
+		scale    int
+		data     int
+		expected string
+	}{
+		{"two decimals", 12300, 12355, 2, 45, "123.45"},
+		{"negative below one", -5, 0, 2, 2, "-0.03"},
+		{"no decimals", -5, 0, 0, 2, "-3"},
+		{"leading zeros", 0, 7, 3, 7, "0.007"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStaticServer(t, fmt.Sprintf(`{"type":"uint8","length":1,"data":[%d],"success":true}`, tt.data))
+			defer server.Close()
+
+			client := qrng.NewClient()
+			client.APIEndpoint = server.URL
+
+			s, err := client.GetRandomDecimal(tt.min, tt.max, tt.scale)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if s != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, s)
+			}
+		})
+	}
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomDecimal(0, 10, -1); err == nil {
+			t.Error("Expected error for negative scale")
+		}
+		if _, err := client.GetRandomDecimal(10, 0, 2); err == nil {
+			t.Error("Expected error for min > max")
+		}
+	})
+
+	t.Run("bounds beyond int", func(t *testing.T) {
+		if strconv.IntSize == 64 {
+			t.Skip("int holds every int64 bound")
+		}
+		client := qrng.NewClient()
+		if _, err := client.GetRandomDecimal(0, 1<<40, 2); !errors.Is(err, qrng.ErrRangeTooLarge) {
+			t.Errorf("Expected ErrRangeTooLarge, got %v", err)
+		}
+	})
+}
+
+func TestGetRandomBase62(t *testing.T) {
+	t.Run("maps and rejects", func(t *testing.T) {
+		// 62 and 63 fall outside the alphabet and are redrawn
+		server := newStaticServer(t, `{"type":"uint8","length":4,"data":[0,10,62,61],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		s, err := client.GetRandomBase62(3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if s != "0A0" {
+			t.Errorf("Expected 0A0, got %s", s)
+		}
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		if _, err := qrng.NewClient().GetRandomBase62(0); err == nil {
+			t.Error("Expected error for length 0")
+		}
+	})
+}
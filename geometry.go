@@ -0,0 +1,96 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// GetRandomUnitVector returns a direction drawn uniformly from the unit
+// sphere in dim dimensions. It normalises a vector of dim independent
+// standard normals, which is rotationally symmetric (Marsaglia's method), so
+// no direction is favoured. The normals for one vector are fetched in a
+// single batch; the all-zero vector, for which no direction exists, is
+// discarded and redrawn.
+func (c *QRNGClient) GetRandomUnitVector(dim int) ([]float64, error) {
+	if dim < 1 {
+		return nil, errors.New("dim must be at least 1")
+	}
+
+	for {
+		v, err := c.normals(context.Background(), dim)
+		if err != nil {
+			return nil, err
+		}
+
+		norm := 0.0
+		for _, x := range v {
+			norm = math.Hypot(norm, x)
+		}
+		if norm == 0 {
+			continue
+		}
+
+		for i := range v {
+			v[i] /= norm
+		}
+		return v, nil
+	}
+}
+
+// GetRandomQuaternion returns a unit quaternion drawn uniformly over all 3D
+// rotations, ordered w, x, y, z with the scalar part first. It uses
+// Shoemake's method: three quantum uniforms u1, u2, u3 fetched in one batch
+// give
+//
+//	w = sqrt(u1)*cos(2πu3)    x = sqrt(1-u1)*sin(2πu2)
+//	y = sqrt(1-u1)*cos(2πu2)  z = sqrt(u1)*sin(2πu3)
+//
+// which, unlike uniformly sampled Euler angles, favours no orientation. q
+// and -q describe the same rotation, so either sign may be returned.
+func (c *QRNGClient) GetRandomQuaternion() ([4]float64, error) {
+	u, err := c.uniforms(context.Background(), 3)
+	if err != nil {
+		return [4]float64{}, err
+	}
+
+	r1, r2 := math.Sqrt(1-u[0]), math.Sqrt(u[0])
+	s2, c2 := math.Sincos(2 * math.Pi * u[1])
+	s3, c3 := math.Sincos(2 * math.Pi * u[2])
+	return [4]float64{r2 * c3, r1 * s2, r1 * c2, r2 * s3}, nil
+}
+
+// GetRandomPointInUnitDisk returns a point drawn uniformly from the disk of
+// radius 1 centred on the origin. Two quantum uniforms u1, u2 fetched in one
+// batch give the radius sqrt(u1) and the angle 2πu2; the square root
+// matters, since a radius uniform in [0,1) would crowd points towards the
+// centre, where rings of equal width hold less area.
+func (c *QRNGClient) GetRandomPointInUnitDisk() (x, y float64, err error) {
+	u, err := c.uniforms(context.Background(), 2)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	r := math.Sqrt(u[0])
+	sin, cos := math.Sincos(2 * math.Pi * u[1])
+	return r * cos, r * sin, nil
+}
+
+// GetRandomPointInUnitBall returns a point drawn uniformly from the ball of
+// radius 1 centred on the origin. Three quantum uniforms fetched in one
+// batch give the radius as the cube root of the first, then a direction
+// uniform on the sphere from the cosine of the polar angle, 1-2u2, and the
+// azimuth 2πu3. Taking the cube root keeps the density uniform by volume
+// rather than by radius.
+func (c *QRNGClient) GetRandomPointInUnitBall() (x, y, z float64, err error) {
+	u, err := c.uniforms(context.Background(), 3)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	r := math.Cbrt(u[0])
+	cosTheta := 1 - 2*u[1]
+	sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+	sin, cos := math.Sincos(2 * math.Pi * u[2])
+	return r * sinTheta * cos, r * sinTheta * sin, r * cosTheta, nil
+}
@@ -0,0 +1,137 @@
+package qrng_test
+
+import (
+	"math"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestGetRandomUnitVector(t *testing.T) {
+	t.Run("normalises normals", func(t *testing.T) {
+		// radius 2 at angles 0 and 0.25 turns gives normals (2, 0) and (0, 2)
+		server := uniformSequenceServer(t, 1-math.Exp(-2), 0, 1-math.Exp(-2), 0.25)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		v, err := client.GetRandomUnitVector(3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		// (2, 0, 0) normalised
+		if len(v) != 3 || math.Abs(v[0]-1) > 1e-9 || math.Abs(v[1]) > 1e-9 || math.Abs(v[2]) > 1e-9 {
+			t.Errorf("Expected (1, 0, 0), got %v", v)
+		}
+	})
+
+	t.Run("resamples the zero vector", func(t *testing.T) {
+		// u1 = 0 gives radius 0, so the first vector is all zeros
+		server := uniformSequenceServer(t, 0, 0, 1-math.Exp(-2), 0.5)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		v, err := client.GetRandomUnitVector(1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if math.Abs(v[0]+1) > 1e-9 {
+			t.Errorf("Expected [-1], got %v", v)
+		}
+	})
+
+	t.Run("invalid dimension", func(t *testing.T) {
+		if _, err := qrng.NewClient().GetRandomUnitVector(0); err == nil {
+			t.Error("Expected error for dim 0")
+		}
+	})
+}
+
+func TestGetRandomQuaternion(t *testing.T) {
+	tests := []struct {
+		name     string
+		u        []float64
+		expected [4]float64
+	}{
+		{"zero uniforms", []float64{0, 0, 0}, [4]float64{0, 0, 1, 0}},
+		{"half and quarter turn", []float64{0.5, 0.25, 0}, [4]float64{math.Sqrt(0.5), math.Sqrt(0.5), 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := uniformSequenceServer(t, tt.u...)
+			defer server.Close()
+
+			client := qrng.NewClient()
+			client.APIEndpoint = server.URL
+
+			q, err := client.GetRandomQuaternion()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			norm := 0.0
+			for i, v := range q {
+				if math.Abs(v-tt.expected[i]) > 1e-9 {
+					t.Errorf("Expected %v, got %v", tt.expected, q)
+					break
+				}
+				norm += v * v
+			}
+			if math.Abs(norm-1) > 1e-12 {
+				t.Errorf("Expected unit norm, got %v", norm)
+			}
+		})
+	}
+}
+
+func TestGetRandomPointInUnitDisk(t *testing.T) {
+	// u1 = 0.25 gives radius 0.5, not 0.25; u2 = 0.25 is a quarter turn
+	server := uniformSequenceServer(t, 0.25, 0.25)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	x, y, err := client.GetRandomPointInUnitDisk()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(x) > 1e-9 || math.Abs(y-0.5) > 1e-9 {
+		t.Errorf("Expected (0, 0.5), got (%v, %v)", x, y)
+	}
+}
+
+func TestGetRandomPointInUnitBall(t *testing.T) {
+	tests := []struct {
+		name     string
+		u        []float64
+		expected [3]float64
+	}{
+		{"equator", []float64{0.125, 0.5, 0}, [3]float64{0.5, 0, 0}},
+		{"pole", []float64{0.125, 0, 0.75}, [3]float64{0, 0, 0.5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := uniformSequenceServer(t, tt.u...)
+			defer server.Close()
+
+			client := qrng.NewClient()
+			client.APIEndpoint = server.URL
+
+			x, y, z, err := client.GetRandomPointInUnitBall()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got := [3]float64{x, y, z}
+			for i := range got {
+				if math.Abs(got[i]-tt.expected[i]) > 1e-9 {
+					t.Errorf("Expected %v, got %v", tt.expected, got)
+					break
+				}
+			}
+		})
+	}
+}
@@ -1,3 +1,8 @@
 module github.com/albertnieto/anu-qrng-go
 
 go 1.23.5
+
+require (
+	golang.org/x/crypto v0.36.0
+	golang.org/x/sync v0.16.0
+)
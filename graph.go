@@ -0,0 +1,49 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+)
+
+// GetRandomGraph returns the adjacency matrix of an undirected Erdős–Rényi
+// G(n, p) graph: each of the n(n-1)/2 possible edges is present
+// independently with probability p. The matrix is symmetric with a false
+// diagonal, so there are no self-loops. One uniform is drawn per possible
+// edge, all in a single batch, taken in row order over the upper triangle.
+// No fetch is needed when n < 2 or p is 0 or 1.
+func (c *QRNGClient) GetRandomGraph(n int, p float64) ([][]bool, error) {
+	if n < 0 {
+		return nil, errors.New("n must not be negative")
+	}
+	if !(p >= 0 && p <= 1) {
+		return nil, ErrInvalidProbability
+	}
+
+	adj := make([][]bool, n)
+	for i := range adj {
+		adj[i] = make([]bool, n)
+	}
+	if n < 2 || p == 0 {
+		return adj, nil
+	}
+
+	var u []float64
+	if p < 1 {
+		var err error
+		if u, err = c.uniforms(context.Background(), n*(n-1)/2); err != nil {
+			return nil, err
+		}
+	}
+
+	k := 0
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if p == 1 || u[k] < p {
+				adj[i][j] = true
+				adj[j][i] = true
+			}
+			k++
+		}
+	}
+	return adj, nil
+}
@@ -0,0 +1,71 @@
+package qrng_test
+
+import (
+	"errors"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestGetRandomGraph(t *testing.T) {
+	t.Run("edges from upper triangle", func(t *testing.T) {
+		// edges 0-1, 0-2, 1-2 in that order
+		server := uniformSequenceServer(t, 0.1, 0.9, 0.4)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		adj, err := client.GetRandomGraph(3, 0.5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := [][]bool{
+			{false, true, false},
+			{true, false, true},
+			{false, true, false},
+		}
+		for i := range expected {
+			for j := range expected[i] {
+				if adj[i][j] != expected[i][j] {
+					t.Fatalf("Expected %v, got %v", expected, adj)
+				}
+			}
+		}
+	})
+
+	t.Run("degenerate inputs need no fetch", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = "http://127.0.0.1:0"
+
+		empty, err := client.GetRandomGraph(4, 0)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		complete, err := client.GetRandomGraph(4, 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				if empty[i][j] || complete[i][j] != (i != j) {
+					t.Fatalf("Unexpected graphs %v and %v", empty, complete)
+				}
+			}
+		}
+
+		if adj, err := client.GetRandomGraph(1, 0.5); err != nil || len(adj) != 1 || adj[0][0] {
+			t.Errorf("Expected single isolated vertex, got %v (%v)", adj, err)
+		}
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomGraph(-1, 0.5); err == nil {
+			t.Error("Expected error for negative n")
+		}
+		if _, err := client.GetRandomGraph(3, 1.5); !errors.Is(err, qrng.ErrInvalidProbability) {
+			t.Errorf("Expected ErrInvalidProbability, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package qrng
+
+import "time"
+
+// RequestEvent describes one HTTP attempt against the API. Retries produce
+// one event per attempt; values served from the buffered pool produce none.
+type RequestEvent struct {
+	DataType string
+	// Length is the number of values requested
+	Length int
+	// Bytes is the amount of random data received, zero for failed attempts
+	Bytes int
+	// Attempt is 0 for the first try and n for the nth retry
+	Attempt  int
+	Duration time.Duration
+	Err      error
+	// RequestID is the ContextWithRequestID value, if any
+	RequestID string
+}
+
+// OnRequest registers fn to be called after every HTTP attempt, successful
+// or not, for example to export metrics. Unlike OnRefresh, callbacks
+// accumulate: each call adds fn alongside those already registered. fn runs
+// synchronously on the requesting goroutine and must not block.
+func (c *QRNGClient) OnRequest(fn func(RequestEvent)) {
+	if fn == nil {
+		return
+	}
+
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	// copy on write so emitRequest can iterate without holding the lock
+	c.requestHooks = append(c.requestHooks[:len(c.requestHooks):len(c.requestHooks)], fn)
+}
+
+func (c *QRNGClient) emitRequest(ev RequestEvent) {
+	c.hooksMu.Lock()
+	hooks := c.requestHooks
+	c.hooksMu.Unlock()
+
+	for _, fn := range hooks {
+		fn(ev)
+	}
+}
@@ -0,0 +1,34 @@
+package qrng_test
+
+import (
+	"net/http"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestOnRequest(t *testing.T) {
+	server, _ := newFlakyServer(t, 1, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := qrng.NewClient(qrng.WithRetries(2), qrng.WithBackoff(&recordingBackoff{}))
+	client.APIEndpoint = server.URL
+
+	var first, second []qrng.RequestEvent
+	client.OnRequest(func(ev qrng.RequestEvent) { first = append(first, ev) })
+	client.OnRequest(func(ev qrng.RequestEvent) { second = append(second, ev) })
+
+	if _, err := client.GetRandomUint8(1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(first) != 2 || len(second) != 2 {
+		t.Fatalf("Expected 2 events per hook, got %d and %d", len(first), len(second))
+	}
+	if first[0].Err == nil || first[0].Attempt != 0 || first[0].Bytes != 0 {
+		t.Errorf("Unexpected failed attempt event %+v", first[0])
+	}
+	if ev := first[1]; ev.Err != nil || ev.Attempt != 1 || ev.Bytes != 1 || ev.DataType != "uint8" || ev.Length != 1 {
+		t.Errorf("Unexpected retry event %+v", ev)
+	}
+}
@@ -0,0 +1,178 @@
+package qrng
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// idAlphabet is the URL-safe alphabet used by nanoid
+	idAlphabet      = "useandom-26T198340PX75pxJACKVERYMINDBUSHWOLF_GQZbfghjklqvwyzrict"
+	idBitsPerSymbol = 6
+	defaultIDLength = 21
+)
+
+// GetRandomID returns a nanoid-style identifier of the given length drawn from
+// a 64-character URL-safe alphabet. Because the alphabet size is a power of
+// two, every 6 bits of entropy select one symbol with no rejection or bias.
+func (c *QRNGClient) GetRandomID(length int) (string, error) {
+	if limit := c.maxLength() * 8 / idBitsPerSymbol; length < 1 || length > limit {
+		return "", fmt.Errorf("length must be between 1 and %d", limit)
+	}
+
+	bits, err := c.GetRandomBits(length * idBitsPerSymbol)
+	if err != nil {
+		return "", err
+	}
+
+	id := make([]byte, length)
+	for i := range id {
+		idx := 0
+		for _, b := range bits[i*idBitsPerSymbol : (i+1)*idBitsPerSymbol] {
+			idx = idx<<1 | b
+		}
+		id[i] = idAlphabet[idx]
+	}
+	return string(id), nil
+}
+
+// GetRandomIDDefault returns a 21-character identifier, matching nanoid's default size
+func (c *QRNGClient) GetRandomIDDefault() (string, error) {
+	return c.GetRandomID(defaultIDLength)
+}
+
+// GetRandomUUIDv4 returns a random (version 4) UUID in canonical form, with
+// all 122 non-fixed bits drawn from quantum entropy
+func (c *QRNGClient) GetRandomUUIDv4() (string, error) {
+	data, err := c.GetRandomBytes(16)
+	if err != nil {
+		return "", err
+	}
+
+	var u [16]byte
+	copy(u[:], data)
+	u[6] = u[6]&0x0f | 0x40
+	u[8] = u[8]&0x3f | 0x80
+	return formatUUID(u), nil
+}
+
+// GetRandomUUIDv7 returns a time-ordered (version 7) UUID per RFC 9562: the
+// 48-bit Unix millisecond timestamp followed by quantum random bits for the
+// 12-bit rand_a and 62-bit rand_b fields
+func (c *QRNGClient) GetRandomUUIDv7() (string, error) {
+	data, err := c.GetRandomBytes(10)
+	if err != nil {
+		return "", err
+	}
+
+	var u [16]byte
+	ms := uint64(time.Now().UnixMilli())
+	binary.BigEndian.PutUint64(u[:8], ms<<16)
+	copy(u[6:], data)
+	u[6] = u[6]&0x0f | 0x70
+	u[8] = u[8]&0x3f | 0x80
+	return formatUUID(u), nil
+}
+
+func formatUUID(u [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+type stringsConfig struct {
+	unique bool
+}
+
+// StringsOption configures GetRandomStrings
+type StringsOption func(*stringsConfig)
+
+// UniqueStrings makes GetRandomStrings return pairwise distinct strings.
+// Duplicates are regenerated, which costs extra entropy as count approaches
+// the number of possible strings (len(alphabet)^length); when count is below
+// a few percent of that, collisions are rare and the overhead negligible.
+// Requests for more strings than can exist fail immediately.
+func UniqueStrings() StringsOption {
+	return func(cfg *stringsConfig) {
+		cfg.unique = true
+	}
+}
+
+// GetRandomStrings returns count strings of length characters, each drawn
+// uniformly from alphabet. The entropy for all strings is fetched up front in
+// as few requests as the API allows. alphabet may hold any UTF-8 characters
+// but must not repeat one, since a repeated character would be chosen more
+// often than the others.
+func (c *QRNGClient) GetRandomStrings(count, length int, alphabet string, opts ...StringsOption) ([]string, error) {
+	var cfg stringsConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if count < 1 || length < 1 {
+		return nil, errors.New("count and length must be at least 1")
+	}
+	if count > math.MaxInt/length {
+		return nil, ErrRangeTooLarge
+	}
+	if !utf8.ValidString(alphabet) {
+		return nil, errors.New("alphabet must be valid UTF-8")
+	}
+
+	symbols := []rune(alphabet)
+	if len(symbols) == 0 {
+		return nil, errors.New("alphabet must not be empty")
+	}
+	seen := make(map[rune]struct{}, len(symbols))
+	for _, r := range symbols {
+		if _, dup := seen[r]; dup {
+			return nil, fmt.Errorf("alphabet repeats %q", r)
+		}
+		seen[r] = struct{}{}
+	}
+
+	if cfg.unique && float64(count) > math.Pow(float64(len(symbols)), float64(length)) {
+		return nil, fmt.Errorf("only %d^%d distinct strings exist, cannot return %d", len(symbols), length, count)
+	}
+
+	ctx := context.Background()
+	result := make([]string, 0, count)
+	taken := make(map[string]struct{})
+	for len(result) < count {
+		need := count - len(result)
+		idx, err := c.randomInts(ctx, 0, len(symbols)-1, need*length)
+		if err != nil {
+			return nil, err
+		}
+
+		buf := make([]rune, length)
+		for i := 0; i < need; i++ {
+			for j := range buf {
+				buf[j] = symbols[idx[i*length+j]]
+			}
+			s := string(buf)
+			if cfg.unique {
+				if _, dup := taken[s]; dup {
+					continue
+				}
+				taken[s] = struct{}{}
+			}
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
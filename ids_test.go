@@ -0,0 +1,172 @@
+package qrng_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestGetRandomID(t *testing.T) {
+	t.Run("symbol selection", func(t *testing.T) {
+		// 0x00 0x10 0x83 -> 6-bit groups 0, 1, 2, 3
+		server := newStaticServer(t, `{"type":"uint8","length":3,"data":[0,16,131],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		id, err := client.GetRandomID(4)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if id != "usea" {
+			t.Errorf("Expected usea, got %s", id)
+		}
+	})
+
+	t.Run("default length", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":16,"data":[`+strings.Repeat("255,", 15)+`255],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		id, err := client.GetRandomIDDefault()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if id != strings.Repeat("t", 21) {
+			t.Errorf("Unexpected id %s", id)
+		}
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomID(0); err == nil {
+			t.Error("Expected error for invalid length")
+		}
+	})
+}
+
+func TestGetRandomUUID(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":16,"data":[`+strings.Repeat("255,", 15)+`255],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	t.Run("v4", func(t *testing.T) {
+		id, err := client.GetRandomUUIDv4()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if id != "ffffffff-ffff-4fff-bfff-ffffffffffff" {
+			t.Errorf("Unexpected UUID %s", id)
+		}
+	})
+
+	t.Run("v7", func(t *testing.T) {
+		before := time.Now().UnixMilli()
+		id, err := client.GetRandomUUIDv7()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		after := time.Now().UnixMilli()
+
+		if len(id) != 36 || id[14] != '7' || id[19] != 'b' || id[15:18] != "fff" || id[24:] != "ffffffffffff" {
+			t.Errorf("Unexpected UUID layout %s", id)
+		}
+		ms, err := strconv.ParseInt(strings.ReplaceAll(id[:13], "-", ""), 16, 64)
+		if err != nil {
+			t.Fatalf("Unexpected timestamp field: %v", err)
+		}
+		if ms < before || ms > after {
+			t.Errorf("Timestamp %d outside [%d, %d]", ms, before, after)
+		}
+	})
+}
+
+// sequenceServer serves uint8 values from seq in order across requests,
+// cycling, and counts the requests made
+func sequenceServer(t *testing.T, calls *int32, seq ...int) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	next := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		length, _ := strconv.Atoi(r.URL.Query().Get("length"))
+		mu.Lock()
+		data := make([]string, length)
+		for i := range data {
+			data[i] = strconv.Itoa(seq[next%len(seq)])
+			next++
+		}
+		mu.Unlock()
+		fmt.Fprintf(w, `{"type":"uint8","length":%d,"data":[%s],"success":true}`, length, strings.Join(data, ","))
+	}))
+}
+
+func TestGetRandomStrings(t *testing.T) {
+	t.Run("batched generation", func(t *testing.T) {
+		var calls int32
+		server := sequenceServer(t, &calls, 0, 1, 2, 2, 1, 0)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		got, err := client.GetRandomStrings(2, 3, "xyé")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(got) != "[xyé éyx]" {
+			t.Errorf("Expected [xyé éyx], got %v", got)
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 request, got %d", calls)
+		}
+	})
+
+	t.Run("unique regenerates collisions", func(t *testing.T) {
+		var calls int32
+		server := sequenceServer(t, &calls, 0, 0, 1)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		got, err := client.GetRandomStrings(2, 1, "ab", qrng.UniqueStrings())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(got) != "[a b]" {
+			t.Errorf("Expected [a b], got %v", got)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 requests, got %d", calls)
+		}
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		client := qrng.NewClient()
+		for name, call := range map[string]func() error{
+			"zero count":         func() error { _, err := client.GetRandomStrings(0, 4, "ab"); return err },
+			"zero length":        func() error { _, err := client.GetRandomStrings(1, 0, "ab"); return err },
+			"empty alphabet":     func() error { _, err := client.GetRandomStrings(1, 4, ""); return err },
+			"repeated symbol":    func() error { _, err := client.GetRandomStrings(1, 4, "aba"); return err },
+			"too many to unique": func() error { _, err := client.GetRandomStrings(5, 2, "ab", qrng.UniqueStrings()); return err },
+		} {
+			if call() == nil {
+				t.Errorf("%s: expected error", name)
+			}
+		}
+	})
+}
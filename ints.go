@@ -0,0 +1,323 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"math/bits"
+	"unicode/utf8"
+)
+
+// intRange holds the rejection-sampling parameters for a closed range
+type intRange struct {
+	min   int
+	size  int
+	bytes int
+	mask  int
+}
+
+func newIntRange(min, max int) (intRange, error) {
+	if min > max {
+		return intRange{}, ErrInvalidRange
+	}
+
+	size := max - min + 1
+	if size <= 0 {
+		return intRange{}, ErrRangeTooLarge
+	}
+
+	bitSize := 1
+	if size > 1 {
+		bitSize = bits.Len(uint(size - 1))
+	}
+
+	return intRange{
+		min:   min,
+		size:  size,
+		bytes: (bitSize + 7) / 8,
+		mask:  1<<bitSize - 1,
+	}, nil
+}
+
+// fitsInt reports whether v survives conversion to int, which is 32 bits
+// wide on some platforms
+func fitsInt(v int64) bool {
+	return int64(int(v)) == v
+}
+
+// randomInts returns n unbiased integers in [min, max]. Entropy for all n
+// draws is fetched in one batch; only rejected draws trigger another fetch.
+func (c *QRNGClient) randomInts(ctx context.Context, min, max, n int) ([]int, error) {
+	r, err := newIntRange(min, max)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]int, 0, n)
+	for len(result) < n {
+		need := n - len(result)
+		data, err := c.readBytes(ctx, need*r.bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < need; i++ {
+			v := bytesToInt(data[i*r.bytes:(i+1)*r.bytes]) & r.mask
+			if v < r.size {
+				result = append(result, r.min+v)
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetRandomNumbers returns count unbiased integers in [min, max] from one
+// batched fetch
+func (c *QRNGClient) GetRandomNumbers(min, max, count int) ([]int, error) {
+	if count < 1 {
+		return nil, errors.New("count must be at least 1")
+	}
+	return c.randomInts(context.Background(), min, max, count)
+}
+
+// FillRandomInts fills dst in place with unbiased integers in [min, max],
+// fetching entropy for the whole slice in one batch. An empty dst is a no-op
+// that makes no request. On error dst is left unchanged.
+func (c *QRNGClient) FillRandomInts(dst []int, min, max int) error {
+	if _, err := newIntRange(min, max); err != nil {
+		return err
+	}
+	if len(dst) == 0 {
+		return nil
+	}
+
+	values, err := c.randomInts(context.Background(), min, max, len(dst))
+	if err != nil {
+		return err
+	}
+	copy(dst, values)
+	return nil
+}
+
+// GetRandomInterval draws two values in [min, max] from a single batched
+// fetch and returns them ordered so that lo <= hi
+func (c *QRNGClient) GetRandomInterval(min, max int) (lo, hi int, err error) {
+	values, err := c.randomInts(context.Background(), min, max, 2)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	lo, hi = values[0], values[1]
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return lo, hi, nil
+}
+
+// GetRandomFraction returns num/den with den uniform in [1, maxDenominator]
+// and num uniform in [0, den). The fraction is not reduced.
+func (c *QRNGClient) GetRandomFraction(maxDenominator int) (num, den int, err error) {
+	if maxDenominator < 1 {
+		return 0, 0, errors.New("maxDenominator must be at least 1")
+	}
+
+	ctx := context.Background()
+	dens, err := c.randomInts(ctx, 1, maxDenominator, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	den = dens[0]
+
+	nums, err := c.randomInts(ctx, 0, den-1, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	return nums[0], den, nil
+}
+
+// GetRandomFractionReduced is like GetRandomFraction but returns the fraction
+// in lowest terms, so 0 is always reported as 0/1
+func (c *QRNGClient) GetRandomFractionReduced(maxDenominator int) (num, den int, err error) {
+	num, den, err = c.GetRandomFraction(maxDenominator)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	g := gcd(num, den)
+	return num / g, den / g, nil
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// GetRandomSign returns -1 or +1 with equal probability from one quantum bit
+func (c *QRNGClient) GetRandomSign() (int, error) {
+	signs, err := c.GetRandomSigns(1)
+	if err != nil {
+		return 0, err
+	}
+	return signs[0], nil
+}
+
+// GetRandomSigns returns n values of -1 or +1, one bit each: a 1 bit maps to
+// +1 and a 0 bit maps to -1
+func (c *QRNGClient) GetRandomSigns(n int) ([]int, error) {
+	bits, err := c.GetRandomBits(n)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, b := range bits {
+		bits[i] = 2*b - 1
+	}
+	return bits, nil
+}
+
+// ErrEntropyExhausted is returned by ShuffleWithEntropy when the supplied
+// entropy is too short to complete the shuffle.
+var ErrEntropyExhausted = errors.New("recorded entropy exhausted")
+
+// randomBounded returns one unbiased value in [0, bounds[k]] for every k.
+// Entropy for all draws is fetched in one batch and only rejected draws are
+// fetched again.
+func (c *QRNGClient) randomBounded(ctx context.Context, bounds []int) ([]int, error) {
+	return boundedFrom(ctx, bounds, c.readBytes)
+}
+
+// boundedFrom implements randomBounded over any byte source, so the same
+// draws can be replayed from recorded entropy.
+func boundedFrom(ctx context.Context, bounds []int, read func(context.Context, int) ([]byte, error)) ([]int, error) {
+	ranges := make([]intRange, len(bounds))
+	for k, b := range bounds {
+		r, err := newIntRange(0, b)
+		if err != nil {
+			return nil, err
+		}
+		ranges[k] = r
+	}
+
+	result := make([]int, len(bounds))
+	pending := make([]int, len(bounds))
+	for k := range pending {
+		pending[k] = k
+	}
+
+	for len(pending) > 0 {
+		total := 0
+		for _, k := range pending {
+			total += ranges[k].bytes
+		}
+
+		data, err := read(ctx, total)
+		if err != nil {
+			return nil, err
+		}
+
+		rejected := pending[:0]
+		for _, k := range pending {
+			r := ranges[k]
+			v := bytesToInt(data[:r.bytes]) & r.mask
+			data = data[r.bytes:]
+			if v < r.size {
+				result[k] = v
+			} else {
+				rejected = append(rejected, k)
+			}
+		}
+		pending = rejected
+	}
+	return result, nil
+}
+
+// Shuffle randomizes the order of n elements with an unbiased
+// Fisher–Yates shuffle, calling swap to exchange elements i and j. All swap
+// indices are drawn from one batched fetch before swap is first called, so
+// a failed fetch leaves the elements untouched.
+func (c *QRNGClient) Shuffle(n int, swap func(i, j int)) error {
+	if n < 0 {
+		return errors.New("n must not be negative")
+	}
+	if n < 2 {
+		return nil
+	}
+
+	js, err := c.randomBounded(context.Background(), shuffleBounds(n))
+	if err != nil {
+		return err
+	}
+	applySwaps(js, swap)
+	return nil
+}
+
+// ShuffleWithEntropy performs the same Fisher–Yates shuffle as Shuffle but
+// draws its swap indices from entropy instead of the API, so a shuffle can
+// be replayed exactly from bytes recorded earlier. Bytes are consumed in
+// the order Shuffle would fetch them, including redraws of rejected
+// values, and any bytes left over are ignored. If entropy runs out before
+// every index is drawn, ErrEntropyExhausted is returned and swap is never
+// called.
+func ShuffleWithEntropy(n int, swap func(i, j int), entropy []byte) error {
+	if n < 0 {
+		return errors.New("n must not be negative")
+	}
+	if n < 2 {
+		return nil
+	}
+
+	read := func(_ context.Context, size int) ([]byte, error) {
+		if size > len(entropy) {
+			return nil, ErrEntropyExhausted
+		}
+		data := entropy[:size]
+		entropy = entropy[size:]
+		return data, nil
+	}
+
+	js, err := boundedFrom(context.Background(), shuffleBounds(n), read)
+	if err != nil {
+		return err
+	}
+	applySwaps(js, swap)
+	return nil
+}
+
+// shuffleBounds returns the inclusive upper bound of each Fisher–Yates
+// draw for n elements, from the last position down to the second.
+func shuffleBounds(n int) []int {
+	bounds := make([]int, n-1)
+	for k := range bounds {
+		bounds[k] = n - 1 - k
+	}
+	return bounds
+}
+
+func applySwaps(js []int, swap func(i, j int)) {
+	n := len(js) + 1
+	for k, j := range js {
+		if i := n - 1 - k; i != j {
+			swap(i, j)
+		}
+	}
+}
+
+// ShuffleString returns the characters of s in a random order. It shuffles
+// runes rather than bytes, so multibyte UTF-8 characters stay intact;
+// combining sequences such as an accent written as a separate code point
+// are still split. Strings shorter than two runes are returned unchanged
+// without a fetch, and invalid UTF-8 is rejected.
+func (c *QRNGClient) ShuffleString(s string) (string, error) {
+	if !utf8.ValidString(s) {
+		return "", errors.New("s must be valid UTF-8")
+	}
+
+	runes := []rune(s)
+	if err := c.Shuffle(len(runes), func(i, j int) {
+		runes[i], runes[j] = runes[j], runes[i]
+	}); err != nil {
+		return "", err
+	}
+	return string(runes), nil
+}
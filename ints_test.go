@@ -0,0 +1,317 @@
+package qrng_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestGetRandomNumbers(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":3,"data":[1,2,3],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	nums, err := client.GetRandomNumbers(10, 13, 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fmt.Sprint(nums) != "[11 12 13]" {
+		t.Errorf("Expected [11 12 13], got %v", nums)
+	}
+
+	if _, err := client.GetRandomNumbers(0, 1, 0); err == nil {
+		t.Error("Expected error for count 0")
+	}
+}
+
+func TestFillRandomInts(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":3,"data":[1,2,3],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	dst := make([]int, 3)
+	if err := client.FillRandomInts(dst, 10, 13); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fmt.Sprint(dst) != "[11 12 13]" {
+		t.Errorf("Expected [11 12 13], got %v", dst)
+	}
+
+	if err := client.FillRandomInts(nil, 0, 1); err != nil {
+		t.Errorf("Expected empty slice to be a no-op, got %v", err)
+	}
+	if err := client.FillRandomInts(dst, 5, 1); !errors.Is(err, qrng.ErrInvalidRange) {
+		t.Errorf("Expected ErrInvalidRange, got %v", err)
+	}
+}
+
+func TestGetRandomInterval(t *testing.T) {
+	t.Run("ordered result", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":2,"data":[9,3],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		lo, hi, err := client.GetRandomInterval(0, 15)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if lo != 3 || hi != 9 {
+			t.Errorf("Expected (3, 9), got (%d, %d)", lo, hi)
+		}
+	})
+
+	t.Run("rejected draws are refetched", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				fmt.Fprintln(w, `{"type":"uint8","length":2,"data":[12,4],"success":true}`)
+				return
+			}
+			fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[7],"success":true}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		// range size 10 uses a 4-bit mask, so 12 is rejected and only one
+		// more value is fetched
+		lo, hi, err := client.GetRandomInterval(0, 9)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if lo != 4 || hi != 7 {
+			t.Errorf("Expected (4, 7), got (%d, %d)", lo, hi)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 requests, got %d", calls)
+		}
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, _, err := client.GetRandomInterval(5, 1); !errors.Is(err, qrng.ErrInvalidRange) {
+			t.Errorf("Expected ErrInvalidRange, got %v", err)
+		}
+	})
+}
+
+func TestGetRandomFraction(t *testing.T) {
+	newServer := func() *httptest.Server {
+		var calls int32
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// denominator draw 5 -> 6, then numerator draw 4 -> 4/6
+			if atomic.AddInt32(&calls, 1) == 1 {
+				fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[5],"success":true}`)
+				return
+			}
+			fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[4],"success":true}`)
+		}))
+	}
+
+	t.Run("unreduced", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		num, den, err := client.GetRandomFraction(8)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if num != 4 || den != 6 {
+			t.Errorf("Expected 4/6, got %d/%d", num, den)
+		}
+	})
+
+	t.Run("reduced", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		num, den, err := client.GetRandomFractionReduced(8)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if num != 2 || den != 3 {
+			t.Errorf("Expected 2/3, got %d/%d", num, den)
+		}
+	})
+
+	t.Run("invalid maxDenominator", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, _, err := client.GetRandomFraction(0); err == nil {
+			t.Error("Expected error for maxDenominator 0")
+		}
+	})
+}
+
+func TestGetRandomSigns(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":1,"data":[178],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	t.Run("bit mapping", func(t *testing.T) {
+		signs, err := client.GetRandomSigns(8)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		// 178 = 10110010
+		expected := []int{1, -1, 1, 1, -1, -1, 1, -1}
+		if fmt.Sprint(signs) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, got %v", expected, signs)
+		}
+	})
+
+	t.Run("single sign", func(t *testing.T) {
+		sign, err := client.GetRandomSign()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if sign != 1 {
+			t.Errorf("Expected 1, got %d", sign)
+		}
+	})
+
+	t.Run("invalid n", func(t *testing.T) {
+		if _, err := client.GetRandomSigns(0); err == nil {
+			t.Error("Expected error for n 0")
+		}
+	})
+}
+
+func TestShuffle(t *testing.T) {
+	t.Run("permutes elements", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		if err := client.Shuffle(len(items), func(i, j int) {
+			items[i], items[j] = items[j], items[i]
+		}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		seen := make(map[int]bool)
+		for _, v := range items {
+			seen[v] = true
+		}
+		if len(seen) != 10 {
+			t.Errorf("Shuffle lost elements: %v", items)
+		}
+	})
+
+	t.Run("trivial sizes need no fetch", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = "http://127.0.0.1:0"
+		if err := client.Shuffle(1, func(i, j int) { t.Error("unexpected swap") }); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if err := client.Shuffle(-1, func(i, j int) {}); err == nil {
+			t.Error("Expected error for negative n")
+		}
+	})
+}
+
+func TestShuffleWithEntropy(t *testing.T) {
+	shuffled := func(shuffle func(swap func(i, j int)) error) ([]int, error) {
+		items := []int{0, 1, 2, 3, 4}
+		err := shuffle(func(i, j int) { items[i], items[j] = items[j], items[i] })
+		return items, err
+	}
+
+	t.Run("replays a live shuffle", func(t *testing.T) {
+		entropy := []byte{2, 1, 0, 1}
+		server := newStaticServer(t, `{"type":"uint8","length":4,"data":[2,1,0,1],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		live, err := shuffled(func(swap func(i, j int)) error { return client.Shuffle(5, swap) })
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		replayed, err := shuffled(func(swap func(i, j int)) error { return qrng.ShuffleWithEntropy(5, swap, entropy) })
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !slices.Equal(live, replayed) {
+			t.Errorf("Expected %v, got %v", live, replayed)
+		}
+	})
+
+	t.Run("redraws rejected values", func(t *testing.T) {
+		// 7 is out of range for the first draw and is redrawn from the fifth byte
+		items, err := shuffled(func(swap func(i, j int)) error {
+			return qrng.ShuffleWithEntropy(5, swap, []byte{7, 2, 1, 0, 1, 99})
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		want := []int{3, 0, 4, 2, 1}
+		if !slices.Equal(items, want) {
+			t.Errorf("Expected %v, got %v", want, items)
+		}
+	})
+
+	t.Run("short entropy", func(t *testing.T) {
+		err := qrng.ShuffleWithEntropy(5, func(i, j int) { t.Error("unexpected swap") }, []byte{7, 2, 1, 0})
+		if !errors.Is(err, qrng.ErrEntropyExhausted) {
+			t.Errorf("Expected ErrEntropyExhausted, got %v", err)
+		}
+	})
+}
+
+func TestShuffleString(t *testing.T) {
+	t.Run("shuffles runes", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":4,"data":[0,0,0,0],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		// every draw selects index 0, swapping it with 4, 3, 2 and 1 in turn
+		s, err := client.ShuffleString("héllo")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if s != "élloh" {
+			t.Errorf("Expected élloh, got %s", s)
+		}
+	})
+
+	t.Run("degenerate input", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = "http://127.0.0.1:0"
+		for _, in := range []string{"", "ü"} {
+			if s, err := client.ShuffleString(in); err != nil || s != in {
+				t.Errorf("Expected %q unchanged, got %q (%v)", in, s, err)
+			}
+		}
+		if _, err := client.ShuffleString("a\xffb"); err == nil {
+			t.Error("Expected error for invalid UTF-8")
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package qrng
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// maxDerivedKeyLength is the most HKDF-SHA256 can expand to (255 blocks)
+const maxDerivedKeyLength = 255 * sha256.Size
+
+// GetRandomDerivedKey fetches 32 bytes of quantum input keying material and
+// expands it with HKDF-SHA256 (RFC 5869) into length bytes of key material.
+// salt may be nil, which HKDF treats as a zero-filled salt. An optional info
+// value binds the key to a context such as a protocol label; at most one may
+// be given. length must be between 1 and 8160.
+//
+// The input keying material always comes straight from the API: it is never
+// served from the buffered pool or a seed file, and PostProcess is not
+// applied to it. HKDF cannot add entropy: the result holds at most 256 bits
+// however long it is.
+func (c *QRNGClient) GetRandomDerivedKey(length int, salt []byte, info ...[]byte) ([]byte, error) {
+	if length < 1 || length > maxDerivedKeyLength {
+		return nil, fmt.Errorf("length must be between 1 and %d", maxDerivedKeyLength)
+	}
+	if len(info) > 1 {
+		return nil, errors.New("at most one info value may be given")
+	}
+
+	qr, err := c.doRequest(context.Background(), sha256.Size, "uint8", 0)
+	if err != nil {
+		return nil, err
+	}
+	secret := convertUint8(qr.Data)
+
+	var hkdfInfo []byte
+	if len(info) == 1 {
+		hkdfInfo = info[0]
+	}
+
+	key := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, hkdfInfo), key); err != nil {
+		return nil, fmt.Errorf("hkdf expansion failed: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,95 @@
+package qrng_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/hkdf"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestGetRandomDerivedKey(t *testing.T) {
+	secret := make([]byte, 32)
+	values := make([]string, 32)
+	for i := range secret {
+		secret[i] = byte(i)
+		values[i] = fmt.Sprint(i)
+	}
+	server := newStaticServer(t, `{"type":"uint8","length":32,"data":[`+strings.Join(values, ",")+`],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	salt := []byte("salt")
+
+	t.Run("matches HKDF-SHA256", func(t *testing.T) {
+		key, err := client.GetRandomDerivedKey(42, salt, []byte("app/v1"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := make([]byte, 42)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, []byte("app/v1")), expected); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !bytes.Equal(key, expected) {
+			t.Errorf("Expected %x, got %x", expected, key)
+		}
+	})
+
+	t.Run("info changes the key", func(t *testing.T) {
+		a, err := client.GetRandomDerivedKey(32, salt)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		b, err := client.GetRandomDerivedKey(32, salt, []byte("other"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if bytes.Equal(a, b) {
+			t.Error("Expected different keys for different info")
+		}
+	})
+
+	t.Run("bypasses the pool and PostProcess", func(t *testing.T) {
+		pooled := qrng.NewClient()
+		pooled.APIEndpoint = server.URL
+		pooled.BufferSize = 64
+		pooled.PostProcess = func(data []byte) ([]byte, error) {
+			return make([]byte, len(data)), nil
+		}
+
+		key, err := pooled.GetRandomDerivedKey(32, salt)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := make([]byte, 32)
+		if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, nil), expected); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !bytes.Equal(key, expected) {
+			t.Errorf("Expected key from raw API data %x, got %x", expected, key)
+		}
+	})
+
+	t.Run("at most one info", func(t *testing.T) {
+		if _, err := client.GetRandomDerivedKey(32, salt, []byte("a"), []byte("b")); err == nil {
+			t.Error("Expected error for two info values")
+		}
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		for _, length := range []int{0, 255*32 + 1} {
+			if _, err := client.GetRandomDerivedKey(length, salt); err == nil {
+				t.Errorf("Expected error for length %d", length)
+			}
+		}
+	})
+}
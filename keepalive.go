@@ -0,0 +1,121 @@
+package qrng
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// keepAlive tracks the background pinger started by WithKeepAlive
+type keepAlive struct {
+	mu      sync.Mutex
+	started bool
+	closed  bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// WithKeepAlive keeps the connection to the API warm for low-traffic
+// services: whenever interval passes without any request, the client sends
+// a one-value request in the background, as CheckAvailability does, so the
+// next real call can reuse the pooled connection instead of paying a fresh
+// TLS handshake. Pinging starts with the first request, since until then
+// there is no connection to keep. Pick an interval below the idle timeout
+// of the transport and of any proxy in between.
+//
+// Each ping costs one request of API quota and counts against WithQuota;
+// pings are skipped while either the client-side quota or the quota the
+// server last reported via RateLimitStatus is exhausted. Ping failures are
+// ignored, though they are still reported to OnRequest callbacks. Call Close
+// to stop the pinger. An interval of zero or less disables it. Clones do not
+// ping unless given their own WithKeepAlive.
+func WithKeepAlive(interval time.Duration) Option {
+	return func(c *QRNGClient) {
+		c.keepAliveInterval = interval
+	}
+}
+
+// Close stops background work started by the client, currently the
+// WithKeepAlive pinger, and waits for an in-flight ping to finish. It does
+// not close the HTTPClient, which may be shared with clones. Close is safe
+// to call more than once and on clients without background work; the
+// client stays usable for ordinary requests afterwards, without pings.
+func (c *QRNGClient) Close() error {
+	ka := &c.keepAlive
+	ka.mu.Lock()
+	ka.closed = true
+	cancel, done := ka.cancel, ka.done
+	ka.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	return nil
+}
+
+// startKeepAlive launches the pinger on the client's first request
+func (c *QRNGClient) startKeepAlive() {
+	if c.keepAliveInterval <= 0 {
+		return
+	}
+
+	ka := &c.keepAlive
+	ka.mu.Lock()
+	defer ka.mu.Unlock()
+	if ka.started || ka.closed {
+		return
+	}
+	ka.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ka.cancel = cancel
+	ka.done = make(chan struct{})
+	interval := c.keepAliveInterval
+
+	go func() {
+		defer close(ka.done)
+
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				if c.idleFor() >= interval && c.canPing() {
+					c.CheckAvailability(ctx)
+				}
+				// wake when the connection will have been idle for interval,
+				// counting from the last request or ping, rather than on a
+				// fixed tick that a ping just before it would skip
+				wait := interval - c.idleFor()
+				if wait <= 0 {
+					wait = interval
+				}
+				timer.Reset(wait)
+			}
+		}
+	}()
+}
+
+// touch records that a request is being made
+func (c *QRNGClient) touch() {
+	c.lastActivity.Store(time.Now().UnixNano())
+	c.startKeepAlive()
+}
+
+// idleFor returns the time since the last request started
+func (c *QRNGClient) idleFor() time.Duration {
+	return time.Duration(time.Now().UnixNano() - c.lastActivity.Load())
+}
+
+// canPing reports whether a keep-alive ping fits within the known quotas
+func (c *QRNGClient) canPing() bool {
+	if c.RemainingQuota() == 0 {
+		return false
+	}
+	remaining, resetAt, ok := c.RateLimitStatus()
+	return !ok || remaining > 0 || (!resetAt.IsZero() && time.Now().After(resetAt))
+}
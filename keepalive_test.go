@@ -0,0 +1,98 @@
+package qrng_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestWithKeepAlive(t *testing.T) {
+	t.Run("pings while idle until closed", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		var attempts atomic.Int32
+		client := qrng.NewClient(qrng.WithKeepAlive(10 * time.Millisecond))
+		client.APIEndpoint = server.URL
+		client.OnRequest(func(qrng.RequestEvent) { attempts.Add(1) })
+
+		time.Sleep(30 * time.Millisecond)
+		if got := attempts.Load(); got != 0 {
+			t.Fatalf("Expected no pings before the first request, got %d", got)
+		}
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		if err := client.Close(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		// attempts are reported before the pinger exits, so Close orders them
+		pings := attempts.Load()
+		if pings < 3 {
+			t.Errorf("Expected the request and at least 2 pings, got %d", pings)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		if got := attempts.Load(); got != pings {
+			t.Errorf("Expected no pings after Close, got %d more", got-pings)
+		}
+		if err := client.Close(); err != nil {
+			t.Errorf("Unexpected error on second Close: %v", err)
+		}
+	})
+
+	t.Run("pings once per idle interval", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		var attempts atomic.Int32
+		client := qrng.NewClient(qrng.WithKeepAlive(20 * time.Millisecond))
+		client.APIEndpoint = server.URL
+		client.OnRequest(func(qrng.RequestEvent) { attempts.Add(1) })
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		time.Sleep(410 * time.Millisecond)
+		client.Close()
+
+		// about 20 pings; a ping that counts as activity and so skips the
+		// next check gives 15 or fewer
+		if pings := attempts.Load() - 1; pings < 17 {
+			t.Errorf("Expected a ping every interval, got %d pings in 20 intervals", pings)
+		}
+	})
+
+	t.Run("respects the quota", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient(
+			qrng.WithKeepAlive(10*time.Millisecond),
+			qrng.WithQuota(2, time.Hour),
+		)
+		client.APIEndpoint = server.URL
+		defer client.Close()
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		time.Sleep(100 * time.Millisecond)
+		if got := atomic.LoadInt32(&calls); got != 2 {
+			t.Errorf("Expected the request and one ping within the quota of 2, got %d", got)
+		}
+	})
+
+	t.Run("close without keep-alive", func(t *testing.T) {
+		if err := qrng.NewClient().Close(); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
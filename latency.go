@@ -0,0 +1,61 @@
+package qrng
+
+import (
+	"sync"
+	"time"
+)
+
+// latencyRing keeps the most recent request durations
+type latencyRing struct {
+	mu   sync.Mutex
+	buf  []time.Duration
+	next int
+	full bool
+}
+
+func newLatencyRing(n int) *latencyRing {
+	return &latencyRing{buf: make([]time.Duration, n)}
+}
+
+func (r *latencyRing) add(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buf[r.next] = d
+	r.next = (r.next + 1) % len(r.buf)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *latencyRing) snapshot() []time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.full {
+		return append([]time.Duration(nil), r.buf[:r.next]...)
+	}
+	return append(append([]time.Duration(nil), r.buf[r.next:]...), r.buf[:r.next]...)
+}
+
+// WithLatencyHistory keeps the durations of the last n HTTP attempts,
+// retries and failures included, for RecentLatencies. It is meant for quick
+// diagnostics such as a debug endpoint; use OnRequest or the prometheus
+// subpackage for real monitoring. n of zero or less disables the history.
+func WithLatencyHistory(n int) Option {
+	return func(c *QRNGClient) {
+		c.latencies = nil
+		if n > 0 {
+			c.latencies = newLatencyRing(n)
+		}
+	}
+}
+
+// RecentLatencies returns the recorded request durations, oldest first, or
+// nil if WithLatencyHistory was not given
+func (c *QRNGClient) RecentLatencies() []time.Duration {
+	if c.latencies == nil {
+		return nil
+	}
+	return c.latencies.snapshot()
+}
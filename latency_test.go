@@ -0,0 +1,46 @@
+package qrng_test
+
+import (
+	"net/http"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestWithLatencyHistory(t *testing.T) {
+	server, _ := newFlakyServer(t, 0, http.StatusOK)
+	defer server.Close()
+
+	client := qrng.NewClient(qrng.WithLatencyHistory(3))
+	client.APIEndpoint = server.URL
+
+	if got := client.RecentLatencies(); len(got) != 0 {
+		t.Errorf("Expected empty history, got %v", got)
+	}
+
+	var events []qrng.RequestEvent
+	client.OnRequest(func(ev qrng.RequestEvent) { events = append(events, ev) })
+
+	for i := 0; i < 5; i++ {
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	got := client.RecentLatencies()
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 latencies, got %d", len(got))
+	}
+	for i, d := range got {
+		if d != events[i+2].Duration {
+			t.Errorf("Latency %d: expected %v, got %v", i, events[i+2].Duration, d)
+		}
+	}
+
+	if clone := client.Clone(); len(clone.RecentLatencies()) != 0 {
+		t.Error("Expected clone to start with an empty history")
+	}
+	if qrng.NewClient().RecentLatencies() != nil {
+		t.Error("Expected nil without WithLatencyHistory")
+	}
+}
@@ -0,0 +1,55 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+)
+
+// GetRandomLatinSquare returns an n×n Latin square over the symbols 0 to
+// n-1: every symbol appears exactly once in each row and each column. It
+// starts from the cyclic square (i+j) mod n and applies independent
+// uniformly random permutations to its rows, columns and symbols, all
+// drawn in one batch. The result is uniform over the squares reachable
+// that way, which for n ≥ 4 is only a fraction of all Latin squares; it is
+// meant for puzzles and test data, not for sampling Latin squares
+// uniformly. n of 1 needs no fetch.
+func (c *QRNGClient) GetRandomLatinSquare(n int) ([][]int, error) {
+	if n < 1 {
+		return nil, errors.New("n must be at least 1")
+	}
+
+	perms := [3][]int{identity(n), identity(n), identity(n)}
+	if n > 1 {
+		bounds := make([]int, 0, len(perms)*(n-1))
+		for range perms {
+			bounds = append(bounds, shuffleBounds(n)...)
+		}
+		js, err := c.randomBounded(context.Background(), bounds)
+		if err != nil {
+			return nil, err
+		}
+		for k, perm := range perms {
+			applySwaps(js[k*(n-1):(k+1)*(n-1)], func(i, j int) {
+				perm[i], perm[j] = perm[j], perm[i]
+			})
+		}
+	}
+
+	rows, cols, symbols := perms[0], perms[1], perms[2]
+	square := make([][]int, n)
+	for i := range square {
+		square[i] = make([]int, n)
+		for j := range square[i] {
+			square[i][j] = symbols[(rows[i]+cols[j])%n]
+		}
+	}
+	return square, nil
+}
+
+func identity(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	return perm
+}
@@ -0,0 +1,77 @@
+package qrng_test
+
+import (
+	"fmt"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func isLatinSquare(square [][]int) bool {
+	n := len(square)
+	for i := 0; i < n; i++ {
+		row, col := make([]bool, n), make([]bool, n)
+		for j := 0; j < n; j++ {
+			if len(square[i]) != n {
+				return false
+			}
+			r, c := square[i][j], square[j][i]
+			if r < 0 || r >= n || c < 0 || c >= n || row[r] || col[c] {
+				return false
+			}
+			row[r], col[c] = true, true
+		}
+	}
+	return true
+}
+
+func TestGetRandomLatinSquare(t *testing.T) {
+	t.Run("valid squares", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		for _, n := range []int{2, 3, 5, 9, 16} {
+			square, err := client.GetRandomLatinSquare(n)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(square) != n || !isLatinSquare(square) {
+				t.Errorf("Not a %d×%d Latin square: %v", n, n, square)
+			}
+		}
+	})
+
+	t.Run("permutes the cyclic square", func(t *testing.T) {
+		// all-zero draws swap the last position with the first each time,
+		// giving rows, columns and symbols ordered [1 2 0]
+		server := newStaticServer(t, `{"type":"uint8","length":6,"data":[0,0,0,0,0,0],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		square, err := client.GetRandomLatinSquare(3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got := fmt.Sprint(square); got != "[[0 1 2] [1 2 0] [2 0 1]]" {
+			t.Errorf("Unexpected square %s", got)
+		}
+	})
+
+	t.Run("trivial and invalid sizes", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = "http://127.0.0.1:0"
+
+		if square, err := client.GetRandomLatinSquare(1); err != nil || fmt.Sprint(square) != "[[0]]" {
+			t.Errorf("Expected [[0]], got %v (%v)", square, err)
+		}
+		if _, err := client.GetRandomLatinSquare(0); err == nil {
+			t.Error("Expected error for n = 0")
+		}
+	})
+}
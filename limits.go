@@ -0,0 +1,88 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Limits describes the request bounds enforced by the API
+type Limits struct {
+	// MaxLength is the largest number of values one request may ask for
+	MaxLength int
+}
+
+var errLimitNotReported = errors.New("API did not report its length limit")
+
+var limitNumber = regexp.MustCompile(`\d[\d,]*`)
+
+// DiscoverLimits probes the API with a request one value longer than the
+// current limit and reads the real maximum from the API's answer, either the
+// numbers in the error or info message of a success:false rejection or the
+// length of a silently truncated response. The result is cached: later
+// length validation and request chunking use it instead of the built-in
+// 1024.
+//
+// If the API accepts the oversized probe, its length becomes the new limit,
+// a lower bound on the true maximum, and the probe's data is discarded. If
+// the rejection carries no usable number, or the probe fails for any other
+// reason such as a transport error or an HTTP error status, the error is
+// returned and the current limit is kept. The probe consumes quota like any
+// request.
+func (c *QRNGClient) DiscoverLimits(ctx context.Context) (Limits, error) {
+	ctx, cancel := withTimeout(ctx, c.Timeouts.Probe)
+	defer cancel()
+
+	probe := c.maxLength() + 1
+
+	_, err := c.doRequest(ctx, probe, "uint8", 0)
+	if err == nil {
+		c.setMaxLength(probe)
+		return Limits{MaxLength: probe}, nil
+	}
+	if ctx.Err() != nil {
+		return Limits{}, err
+	}
+
+	limit := 0
+	var truncated *insufficientDataError
+	var rejected *apiError
+	switch {
+	case errors.As(err, &truncated):
+		if truncated.got < probe {
+			limit = truncated.got
+		}
+	case errors.As(err, &rejected):
+		for _, msg := range append([]string{rejected.message}, rejected.info...) {
+			for _, m := range limitNumber.FindAllString(msg, -1) {
+				if v, convErr := strconv.Atoi(strings.ReplaceAll(m, ",", "")); convErr == nil && v < probe {
+					limit = max(limit, v)
+				}
+			}
+		}
+	default:
+		return Limits{}, err
+	}
+	if limit < 1 {
+		return Limits{}, fmt.Errorf("%w: %w", errLimitNotReported, err)
+	}
+
+	c.setMaxLength(limit)
+	return Limits{MaxLength: limit}, nil
+}
+
+// maxLength returns the discovered per-request limit or the documented
+// default of 1024
+func (c *QRNGClient) maxLength() int {
+	if n := c.discoveredMax.Load(); n > 0 {
+		return int(n)
+	}
+	return maxUint8Length
+}
+
+func (c *QRNGClient) setMaxLength(n int) {
+	c.discoveredMax.Store(int64(n))
+}
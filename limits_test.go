@@ -0,0 +1,143 @@
+package qrng_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestDiscoverLimits(t *testing.T) {
+	t.Run("limit from error message", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			length, _ := strconv.Atoi(r.URL.Query().Get("length"))
+			if length > 500 {
+				fmt.Fprintln(w, `{"success":false,"error":"length must be between 1 and 500"}`)
+				return
+			}
+			data := strings.TrimSuffix(strings.Repeat("1,", length), ",")
+			fmt.Fprintf(w, `{"type":"uint8","length":%d,"data":[%s],"success":true}`, length, data)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		limits, err := client.DiscoverLimits(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if limits.MaxLength != 500 {
+			t.Errorf("Expected 500, got %d", limits.MaxLength)
+		}
+
+		if _, err := client.GetRandomUint8(501); err == nil {
+			t.Error("Expected validation to use the discovered limit")
+		}
+		data, err := client.GetRandomBytes(1200)
+		if err != nil || len(data) != 1200 {
+			t.Errorf("Expected chunked fetch of 1200 bytes, got %d (%v)", len(data), err)
+		}
+
+		requests := 0
+		clone := client.Clone()
+		clone.OnRequest(func(qrng.RequestEvent) { requests++ })
+		if _, err := clone.GetRandomUint8(501); err == nil || !strings.Contains(err.Error(), "500") {
+			t.Errorf("Expected clone to reject 501 against the discovered limit, got %v", err)
+		}
+		if requests != 0 {
+			t.Errorf("Expected clone validation without a request, got %d requests", requests)
+		}
+	})
+
+	t.Run("limit applies to IDs and uniform streams", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			length, _ := strconv.Atoi(r.URL.Query().Get("length"))
+			if length > 40 {
+				fmt.Fprintln(w, `{"success":false,"error":"length must be between 1 and 40"}`)
+				return
+			}
+			data := strings.TrimSuffix(strings.Repeat("0,", length), ",")
+			fmt.Fprintf(w, `{"type":"uint8","length":%d,"data":[%s],"success":true}`, length, data)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		if _, err := client.DiscoverLimits(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		requests := 0
+		client.OnRequest(func(qrng.RequestEvent) { requests++ })
+
+		if _, err := client.GetRandomID(54); err == nil || !strings.Contains(err.Error(), "53") {
+			t.Errorf("Expected GetRandomID to reject 54 against the discovered limit, got %v", err)
+		}
+		// a zero uniform ends Knuth's method after one draw, so only the
+		// first batch is fetched
+		if _, err := client.GetRandomPoisson(5); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if requests != 1 {
+			t.Errorf("Expected the uniform batch to fit one request, made %d", requests)
+		}
+	})
+
+	t.Run("limit from truncated response", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":3,"data":[1,2,3],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		limits, err := client.DiscoverLimits(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if limits.MaxLength != 3 {
+			t.Errorf("Expected 3, got %d", limits.MaxLength)
+		}
+	})
+
+	t.Run("failures keep the limit", func(t *testing.T) {
+		unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}))
+		defer unavailable.Close()
+
+		refused := httptest.NewServer(http.NotFoundHandler())
+		refused.Close()
+
+		for name, endpoint := range map[string]string{"status": unavailable.URL, "transport": refused.URL} {
+			t.Run(name, func(t *testing.T) {
+				client := qrng.NewClient()
+				client.APIEndpoint = endpoint
+
+				if _, err := client.DiscoverLimits(context.Background()); err == nil {
+					t.Fatal("Expected error")
+				}
+				if _, err := client.GetRandomUint8(1025); err == nil || !strings.Contains(err.Error(), "1024") {
+					t.Errorf("Expected the default limit of 1024, got %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("no usable number", func(t *testing.T) {
+		server := newStaticServer(t, `{"success":false,"error":"too long"}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		if _, err := client.DiscoverLimits(context.Background()); err == nil {
+			t.Error("Expected error when the limit is not reported")
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package qrng
+
+import (
+	"errors"
+	"time"
+)
+
+// completionTimeLayouts are the completionTime formats seen across the
+// legacy and authenticated APIs; layouts without a zone are read as UTC
+var completionTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+var ErrUnparsedCompletionTime = errors.New("completionTime not in a recognised format")
+
+// ParseCompletionTime parses CompletionTime using the layouts of every known
+// API variant. ErrUnparsedCompletionTime is informational: the response data
+// is still valid.
+func (r *QRNGResponse) ParseCompletionTime() (time.Time, error) {
+	return parseCompletionTime(r.CompletionTime)
+}
+
+func parseCompletionTime(s string) (time.Time, error) {
+	for _, layout := range completionTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrUnparsedCompletionTime
+}
+
+// ResponseMetadata describes the most recent successful response
+type ResponseMetadata struct {
+	Seed    string
+	Refresh bool
+
+	// CompletionTime is the parsed server timestamp; it is only meaningful
+	// when CompletionTimeParsed is true. RawCompletionTime is always the
+	// string the server sent.
+	CompletionTime       time.Time
+	CompletionTimeParsed bool
+	RawCompletionTime    string
+}
+
+// LastMetadata returns metadata from the most recent successful response and
+// false if no response has been received yet
+func (c *QRNGClient) LastMetadata() (ResponseMetadata, bool) {
+	c.metaMu.Lock()
+	defer c.metaMu.Unlock()
+	return c.lastMeta, c.haveMeta
+}
+
+// LastSeed returns the seed reported by the most recent successful response,
+// or "" if none has been seen
+func (c *QRNGClient) LastSeed() string {
+	c.metaMu.Lock()
+	defer c.metaMu.Unlock()
+	return c.lastSeed
+}
+
+// OnRefresh registers fn to be called when a response reports that the
+// generator refreshed or carries a seed different from the previous one.
+// fn runs synchronously on the requesting goroutine and must not block.
+func (c *QRNGClient) OnRefresh(fn func(oldSeed, newSeed string)) {
+	c.metaMu.Lock()
+	defer c.metaMu.Unlock()
+	c.onRefresh = fn
+}
+
+func (c *QRNGClient) observeMetadata(qr *QRNGResponse) {
+	meta := ResponseMetadata{
+		Seed:              qr.Seed,
+		Refresh:           qr.Refresh,
+		RawCompletionTime: qr.CompletionTime,
+	}
+	if t, err := parseCompletionTime(qr.CompletionTime); err == nil {
+		meta.CompletionTime = t
+		meta.CompletionTimeParsed = true
+	}
+
+	c.metaMu.Lock()
+	c.lastMeta = meta
+	c.haveMeta = true
+	oldSeed := c.lastSeed
+	if qr.Seed != "" {
+		c.lastSeed = qr.Seed
+	}
+	changed := qr.Seed != "" && oldSeed != "" && qr.Seed != oldSeed
+	fn := c.onRefresh
+	c.metaMu.Unlock()
+
+	if fn != nil && (qr.Refresh || changed) {
+		fn(oldSeed, qr.Seed)
+	}
+}
@@ -0,0 +1,114 @@
+package qrng_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestSeedTracking(t *testing.T) {
+	responses := []string{
+		`{"type":"uint8","length":1,"data":[1],"success":true,"seed":"a"}`,
+		`{"type":"uint8","length":1,"data":[1],"success":true,"seed":"a"}`,
+		`{"type":"uint8","length":1,"data":[1],"success":true,"seed":"b"}`,
+		`{"type":"uint8","length":1,"data":[1],"success":true,"seed":"b","refresh":true}`,
+	}
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, responses[atomic.AddInt32(&calls, 1)-1])
+	}))
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	var events []string
+	client.OnRefresh(func(oldSeed, newSeed string) {
+		events = append(events, oldSeed+"->"+newSeed)
+	})
+
+	if client.LastSeed() != "" {
+		t.Errorf("Expected empty seed before any request, got %q", client.LastSeed())
+	}
+
+	for range responses {
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if client.LastSeed() != "b" {
+		t.Errorf("Expected last seed b, got %q", client.LastSeed())
+	}
+	if fmt.Sprint(events) != "[a->b b->b]" {
+		t.Errorf("Unexpected refresh events %v", events)
+	}
+}
+
+func TestParseCompletionTime(t *testing.T) {
+	want := time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)
+	for _, raw := range []string{
+		"2024-03-05T14:07:09Z",
+		"2024-03-05T15:07:09+01:00",
+		"2024-03-05 14:07:09Z",
+		"2024-03-05 14:07:09",
+		"2024-03-05T14:07:09",
+		"Tue, 05 Mar 2024 14:07:09 +0000",
+	} {
+		qr := qrng.QRNGResponse{CompletionTime: raw}
+		got, err := qr.ParseCompletionTime()
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", raw, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("%q: expected %v, got %v", raw, want, got)
+		}
+	}
+
+	qr := qrng.QRNGResponse{CompletionTime: "yesterday"}
+	if _, err := qr.ParseCompletionTime(); !errors.Is(err, qrng.ErrUnparsedCompletionTime) {
+		t.Errorf("Expected ErrUnparsedCompletionTime, got %v", err)
+	}
+}
+
+func TestLastMetadata(t *testing.T) {
+	client := qrng.NewClient()
+	if _, ok := client.LastMetadata(); ok {
+		t.Error("Expected no metadata before any request")
+	}
+
+	t.Run("parsed timestamp", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":1,"data":[1],"success":true,"seed":"s","completionTime":"2024-03-05 14:07:09Z"}`)
+		defer server.Close()
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		meta, ok := client.LastMetadata()
+		if !ok || !meta.CompletionTimeParsed || meta.CompletionTime.Year() != 2024 || meta.Seed != "s" {
+			t.Errorf("Unexpected metadata %+v", meta)
+		}
+	})
+
+	t.Run("unparsed timestamp is non-fatal", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":1,"data":[1],"success":true,"completionTime":"soon"}`)
+		defer server.Close()
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		meta, _ := client.LastMetadata()
+		if meta.CompletionTimeParsed || meta.RawCompletionTime != "soon" {
+			t.Errorf("Unexpected metadata %+v", meta)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package qrng
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"mime"
+	"strings"
+)
+
+// ResponseParser decodes a response body into qr. Parsers for formats other
+// than the API's JSON envelope must set qr.Success themselves, along with
+// qr.Data and any other fields they can recover.
+type ResponseParser func(body []byte, qr *QRNGResponse) error
+
+// maxParseErrorBody bounds the body excerpt kept in a ParseError
+const maxParseErrorBody = 512
+
+// ParseError reports a response body that could not be decoded, such as an
+// HTML error page from a proxy. Body holds at most the first 512 bytes of
+// the response; Truncated is set when more were cut off.
+type ParseError struct {
+	// Format is "json" or the media type of the registered parser that failed
+	Format    string
+	Body      []byte
+	Truncated bool
+	Err       error
+}
+
+func newParseError(format string, body []byte, err error) *ParseError {
+	e := &ParseError{Format: format, Err: err}
+	if len(body) > maxParseErrorBody {
+		body = body[:maxParseErrorBody]
+		e.Truncated = true
+	}
+	e.Body = append([]byte(nil), body...)
+	return e
+}
+
+func (e *ParseError) Error() string {
+	ellipsis := ""
+	if e.Truncated {
+		ellipsis = "..."
+	}
+	return fmt.Sprintf("%s parse error: %v (body %q%s)", e.Format, e.Err, e.Body, ellipsis)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// WithAccept sends the given media types, in order of preference, as the
+// Accept header of every request. The ANU API only serves JSON today; this
+// exists so a proxy or future endpoint can offer other formats, decoded by
+// parsers registered with WithParser.
+func WithAccept(mediaTypes ...string) Option {
+	return func(c *QRNGClient) {
+		c.accept = strings.Join(mediaTypes, ", ")
+	}
+}
+
+// WithParser registers parse for responses whose Content-Type has the given
+// media type, e.g. "text/csv". Parameters such as charset are ignored when
+// matching. Responses with no registered parser, including those without a
+// Content-Type, are decoded as JSON with the client's Unmarshal function.
+func WithParser(mediaType string, parse ResponseParser) Option {
+	return func(c *QRNGClient) {
+		parsers := maps.Clone(c.parsers)
+		if parsers == nil {
+			parsers = make(map[string]ResponseParser)
+		}
+		parsers[strings.ToLower(mediaType)] = parse
+		c.parsers = parsers
+	}
+}
+
+// parseResponse decodes body with the parser registered for contentType,
+// falling back to JSON. body is the complete payload: chunked transfer
+// encoding has already been undone by net/http.
+func (c *QRNGClient) parseResponse(contentType string, body []byte) (QRNGResponse, error) {
+	var qr QRNGResponse
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if parse, ok := c.parsers[mediaType]; ok {
+			if err := parse(body, &qr); err != nil {
+				return qr, newParseError(mediaType, body, err)
+			}
+			return qr, nil
+		}
+	}
+
+	unmarshal := c.Unmarshal
+	if unmarshal == nil {
+		unmarshal = json.Unmarshal
+	}
+	// servers pad bodies with newlines; strip them so stricter or streaming
+	// Unmarshal replacements see exactly one JSON value
+	if err := unmarshal(bytes.TrimSpace(body), &qr); err != nil {
+		return qr, newParseError("json", body, err)
+	}
+	return qr, nil
+}
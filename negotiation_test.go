@@ -0,0 +1,176 @@
+package qrng_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func parseCSV(body []byte, qr *qrng.QRNGResponse) error {
+	for _, field := range strings.Split(strings.TrimSpace(string(body)), ",") {
+		v, err := strconv.Atoi(field)
+		if err != nil {
+			return err
+		}
+		qr.Data = append(qr.Data, v)
+	}
+	qr.Success = true
+	return nil
+}
+
+func TestContentNegotiation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.Header.Get("Accept"), "text/csv") {
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			fmt.Fprintln(w, "7,8,9")
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprintln(w, `{"type":"uint8","length":3,"data":[1,2,3],"success":true}`)
+	}))
+	defer server.Close()
+
+	t.Run("registered parser", func(t *testing.T) {
+		client := qrng.NewClient(
+			qrng.WithAccept("text/csv", "application/json;q=0.5"),
+			qrng.WithParser("text/csv", parseCSV),
+		)
+		client.APIEndpoint = server.URL
+
+		data, err := client.GetRandomUint8(3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(data) != "[7 8 9]" {
+			t.Errorf("Expected [7 8 9], got %v", data)
+		}
+	})
+
+	t.Run("falls back to JSON", func(t *testing.T) {
+		client := qrng.NewClient(qrng.WithParser("text/csv", parseCSV))
+		client.APIEndpoint = server.URL
+
+		data, err := client.GetRandomUint8(3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(data) != "[1 2 3]" {
+			t.Errorf("Expected [1 2 3], got %v", data)
+		}
+	})
+
+	t.Run("parser error surfaced", func(t *testing.T) {
+		client := qrng.NewClient(
+			qrng.WithAccept("text/csv"),
+			qrng.WithParser("text/csv", func([]byte, *qrng.QRNGResponse) error { return strconv.ErrSyntax }),
+		)
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(3); err == nil {
+			t.Error("Expected parse error")
+		}
+	})
+}
+
+func TestResponseFraming(t *testing.T) {
+	const payload = `{"type":"uint8","length":3,"data":[4,5,6],"success":true}`
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		chunked bool
+	}{
+		{"surrounding whitespace", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "\r\n\t "+payload+" \n\n")
+		}, false},
+		{"chunked across writes", func(w http.ResponseWriter, r *http.Request) {
+			for _, part := range []string{payload[:10], payload[10:31], payload[31:], "\n"} {
+				fmt.Fprint(w, part)
+				w.(http.Flusher).Flush()
+			}
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			var chunked bool
+			client := qrng.NewClient(qrng.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				resp, err := http.DefaultTransport.RoundTrip(r)
+				if err == nil {
+					chunked = slices.Contains(resp.TransferEncoding, "chunked")
+				}
+				return resp, err
+			})))
+			client.APIEndpoint = server.URL
+			// a strict decoder that rejects anything around the JSON value
+			client.Unmarshal = func(data []byte, v any) error {
+				if strings.TrimSpace(string(data)) != string(data) {
+					return fmt.Errorf("unexpected whitespace in %q", data)
+				}
+				return json.Unmarshal(data, v)
+			}
+
+			data, err := client.GetRandomUint8(3)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if fmt.Sprint(data) != "[4 5 6]" {
+				t.Errorf("Expected [4 5 6], got %v", data)
+			}
+			if chunked != tt.chunked {
+				t.Errorf("Expected chunked=%v, got %v", tt.chunked, chunked)
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	t.Run("carries the body", func(t *testing.T) {
+		server := newStaticServer(t, `<html>502 Bad Gateway</html>`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		_, err := client.GetRandomUint8(1)
+		var parseErr *qrng.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Expected *ParseError, got %v", err)
+		}
+		if parseErr.Format != "json" || !strings.Contains(string(parseErr.Body), "502 Bad Gateway") || parseErr.Truncated {
+			t.Errorf("Unexpected parse error %+v", parseErr)
+		}
+		var syntaxErr *json.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Expected the underlying *json.SyntaxError, got %v", parseErr.Err)
+		}
+	})
+
+	t.Run("truncates long bodies", func(t *testing.T) {
+		server := newStaticServer(t, strings.Repeat("x", 2000))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		_, err := client.GetRandomUint8(1)
+		var parseErr *qrng.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Expected *ParseError, got %v", err)
+		}
+		if len(parseErr.Body) != 512 || !parseErr.Truncated {
+			t.Errorf("Expected 512 truncated bytes, got %d (truncated %v)", len(parseErr.Body), parseErr.Truncated)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package qrng
+
+import (
+	"errors"
+	"net"
+)
+
+const (
+	minPort          = 1
+	maxPort          = 65535
+	minEphemeralPort = 49152
+)
+
+var (
+	ErrInvalidPortRange = errors.New("port range must be within 1-65535")
+	ErrInvalidPrefix    = errors.New("invalid prefix length for address family")
+)
+
+// GetRandomPort returns a uniform port in the IANA ephemeral range [49152, 65535]
+func (c *QRNGClient) GetRandomPort() (int, error) {
+	return c.GetRandomPortInRange(minEphemeralPort, maxPort)
+}
+
+// GetRandomPortInRange returns a uniform port in [min, max], both within [1, 65535]
+func (c *QRNGClient) GetRandomPortInRange(min, max int) (int, error) {
+	if min < minPort || max > maxPort {
+		return 0, ErrInvalidPortRange
+	}
+
+	return c.GetRandomNumber(min, max)
+}
+
+// GetRandomSubnet returns a random IPv4 network with the given prefix length
+// (0-32) and all host bits zeroed
+func (c *QRNGClient) GetRandomSubnet(prefixLen int) (*net.IPNet, error) {
+	return c.randomSubnet(nil, prefixLen, net.IPv4len*8)
+}
+
+// GetRandomSubnet6 returns a random IPv6 network with the given prefix length
+// (0-128) and all host bits zeroed
+func (c *QRNGClient) GetRandomSubnet6(prefixLen int) (*net.IPNet, error) {
+	return c.randomSubnet(nil, prefixLen, net.IPv6len*8)
+}
+
+// GetRandomSubnetWithin returns a random network of the given prefix length
+// inside parent, in parent's address family. prefixLen must be at least
+// parent's own prefix length.
+func (c *QRNGClient) GetRandomSubnetWithin(parent *net.IPNet, prefixLen int) (*net.IPNet, error) {
+	if parent == nil {
+		return nil, errors.New("parent network must not be nil")
+	}
+	_, bits := parent.Mask.Size()
+	if bits == 0 {
+		return nil, ErrInvalidPrefix
+	}
+	return c.randomSubnet(parent, prefixLen, bits)
+}
+
+func (c *QRNGClient) randomSubnet(parent *net.IPNet, prefixLen, bits int) (*net.IPNet, error) {
+	base := make(net.IP, bits/8)
+	parentMask := net.CIDRMask(0, bits)
+	if parent != nil {
+		if bits == net.IPv4len*8 {
+			base = parent.IP.To4()
+		} else {
+			base = parent.IP.To16()
+		}
+		if base == nil {
+			return nil, ErrInvalidPrefix
+		}
+		parentMask = parent.Mask
+	}
+
+	parentLen, _ := parentMask.Size()
+	if prefixLen < parentLen || prefixLen > bits {
+		return nil, ErrInvalidPrefix
+	}
+
+	ip := make(net.IP, bits/8)
+	if prefixLen > parentLen {
+		data, err := c.GetRandomBytes(len(ip))
+		if err != nil {
+			return nil, err
+		}
+		copy(ip, data)
+	}
+
+	// keep the parent's network bits, randomize the rest of the new prefix
+	// and zero the host bits
+	mask := net.CIDRMask(prefixLen, bits)
+	for i := range ip {
+		ip[i] = (base[i]&parentMask[i] | ip[i]&^parentMask[i]) & mask[i]
+	}
+	return &net.IPNet{IP: ip, Mask: mask}, nil
+}
@@ -0,0 +1,103 @@
+package qrng_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestGetRandomPort(t *testing.T) {
+	t.Run("ephemeral range", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"type":"uint8","length":2,"data":[16,0],"success":true}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		port, err := client.GetRandomPort()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if port != 49152+4096 {
+			t.Errorf("Expected %d, got %d", 49152+4096, port)
+		}
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		client := qrng.NewClient()
+		for _, r := range [][2]int{{0, 100}, {1, 65536}} {
+			_, err := client.GetRandomPortInRange(r[0], r[1])
+			if !errors.Is(err, qrng.ErrInvalidPortRange) {
+				t.Errorf("Expected ErrInvalidPortRange for %v, got %v", r, err)
+			}
+		}
+	})
+}
+
+func TestGetRandomSubnet(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":16,"data":[255,255,255,255,255,255,255,255,255,255,255,255,255,255,255,255],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	t.Run("ipv4", func(t *testing.T) {
+		subnet, err := client.GetRandomSubnet(20)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if subnet.String() != "255.255.240.0/20" {
+			t.Errorf("Expected 255.255.240.0/20, got %s", subnet)
+		}
+	})
+
+	t.Run("ipv6", func(t *testing.T) {
+		subnet, err := client.GetRandomSubnet6(52)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if subnet.String() != "ffff:ffff:ffff:f000::/52" {
+			t.Errorf("Expected ffff:ffff:ffff:f000::/52, got %s", subnet)
+		}
+	})
+
+	t.Run("within parent", func(t *testing.T) {
+		_, parent, _ := net.ParseCIDR("10.20.0.0/16")
+		subnet, err := client.GetRandomSubnetWithin(parent, 24)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if subnet.String() != "10.20.255.0/24" {
+			t.Errorf("Expected 10.20.255.0/24, got %s", subnet)
+		}
+	})
+
+	t.Run("invalid prefix", func(t *testing.T) {
+		if _, err := client.GetRandomSubnet(33); !errors.Is(err, qrng.ErrInvalidPrefix) {
+			t.Errorf("Expected ErrInvalidPrefix, got %v", err)
+		}
+		if _, err := client.GetRandomSubnet(-1); !errors.Is(err, qrng.ErrInvalidPrefix) {
+			t.Errorf("Expected ErrInvalidPrefix, got %v", err)
+		}
+		_, parent, _ := net.ParseCIDR("10.20.0.0/16")
+		if _, err := client.GetRandomSubnetWithin(parent, 8); !errors.Is(err, qrng.ErrInvalidPrefix) {
+			t.Errorf("Expected ErrInvalidPrefix, got %v", err)
+		}
+	})
+
+	t.Run("zero prefix needs no fetch", func(t *testing.T) {
+		offline := qrng.NewClient()
+		offline.APIEndpoint = "http://127.0.0.1:0"
+		subnet, err := offline.GetRandomSubnet(0)
+		if err != nil || subnet.String() != "0.0.0.0/0" {
+			t.Errorf("Expected 0.0.0.0/0, got %v (%v)", subnet, err)
+		}
+	})
+}
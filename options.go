@@ -0,0 +1,287 @@
+package qrng
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTLSPinMismatch       = errors.New("server certificate does not match any pinned fingerprint")
+	ErrUnsupportedTransport = errors.New("transport options require an *http.Transport")
+	ErrInvalidNetwork       = errors.New("network must be tcp, tcp4 or tcp6")
+	ErrInvalidTLSPin        = errors.New("TLS pins must be one or more SHA-256 fingerprints of 64 hex characters")
+)
+
+// dialer settings matching http.DefaultTransport
+const (
+	dialTimeout   = 30 * time.Second
+	dialKeepAlive = 30 * time.Second
+)
+
+// Option configures a QRNGClient at construction time
+type Option func(*QRNGClient)
+
+func (c *QRNGClient) applyOptions(opts []Option) {
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.backoff != nil && c.maxRetries == 0 {
+		c.maxRetries = defaultMaxRetries
+	}
+	if c.maxRetries > 0 && c.backoff == nil {
+		c.backoff = defaultBackoff()
+	}
+
+	c.applyTransportOptions()
+}
+
+// applyTransportOptions applies the collected transport options to a
+// private clone of the HTTP client's transport
+func (c *QRNGClient) applyTransportOptions() {
+	if len(c.transportOpts) == 0 {
+		return
+	}
+
+	hc := c.ownHTTPClient()
+	rt, err := configureTransport(hc.Transport, c.transportOpts)
+	if err != nil {
+		hc.Transport = errorTransport{err}
+		return
+	}
+	hc.Transport = rt
+}
+
+// configureTransport returns a copy of rt with opts applied to the
+// *http.Transport at its core, looking through the wrappers returned by
+// Transport and NewTransport. A nil rt stands for http.DefaultTransport.
+func configureTransport(rt http.RoundTripper, opts []func(*http.Transport) error) (http.RoundTripper, error) {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	switch t := rt.(type) {
+	case *http.Transport:
+		t = t.Clone()
+		for _, opt := range opts {
+			if err := opt(t); err != nil {
+				return nil, err
+			}
+		}
+		return t, nil
+	case *apiTransport:
+		base, err := configureTransport(t.base, opts)
+		if err != nil {
+			return nil, err
+		}
+		return &apiTransport{apiKey: t.apiKey, base: base}, nil
+	default:
+		return nil, ErrUnsupportedTransport
+	}
+}
+
+// ownHTTPClient replaces c.HTTPClient with a private copy so that options
+// never mutate an http.Client shared with another QRNGClient
+func (c *QRNGClient) ownHTTPClient() *http.Client {
+	hc := &http.Client{Timeout: defaultTimeout}
+	if c.HTTPClient != nil {
+		*hc = *c.HTTPClient
+	}
+	c.HTTPClient = hc
+	return hc
+}
+
+// WithAPIKey sets the key sent to the authenticated API
+func WithAPIKey(apiKey string) Option {
+	return func(c *QRNGClient) {
+		c.APIKey = apiKey
+	}
+}
+
+// WithValidateResponseType controls whether every successful response must
+// echo the requested data type in its type field; a response naming another
+// type, for example uint16 data from a misconfigured proxy answering a uint8
+// request, fails with ErrTypeMismatch instead of being decoded as the wrong
+// width. Responses that omit the field are accepted. Validation is on by
+// default; disable it only for servers known to report types differently.
+func WithValidateResponseType(enabled bool) Option {
+	return func(c *QRNGClient) {
+		c.skipTypeCheck = !enabled
+	}
+}
+
+// WithTransport sets the RoundTripper used by the client's HTTP client.
+// Transport-level options such as WithTLSPin are applied on top of it
+// regardless of option order. They need an *http.Transport to configure,
+// either rt itself or the base of a wrapper returned by Transport or
+// NewTransport; other middleware makes requests fail with
+// ErrUnsupportedTransport.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *QRNGClient) {
+		c.ownHTTPClient().Transport = rt
+	}
+}
+
+// WithTLSPin restricts TLS connections to servers whose leaf certificate has
+// one of the given SHA-256 fingerprints (hex, colons optional). The handshake
+// fails on mismatch. The check runs on every connection, including resumed
+// sessions, and chains to any verification already set on the transport.
+// Requests fail with ErrInvalidTLSPin if no fingerprint is given or one is
+// not 64 hex characters once colons are removed.
+func WithTLSPin(sha256Fingerprints ...string) Option {
+	pins := make(map[string]struct{}, len(sha256Fingerprints))
+	valid := len(sha256Fingerprints) > 0
+	for _, fp := range sha256Fingerprints {
+		pin := strings.ToLower(strings.ReplaceAll(fp, ":", ""))
+		if _, err := hex.DecodeString(pin); err != nil || len(pin) != 2*sha256.Size {
+			valid = false
+		}
+		pins[pin] = struct{}{}
+	}
+
+	return func(c *QRNGClient) {
+		c.transportOpts = append(c.transportOpts, func(t *http.Transport) error {
+			if !valid {
+				return ErrInvalidTLSPin
+			}
+
+			cfg := t.TLSClientConfig
+			if cfg == nil {
+				cfg = &tls.Config{}
+			} else {
+				cfg = cfg.Clone()
+			}
+
+			next := cfg.VerifyConnection
+			cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+				if len(cs.PeerCertificates) == 0 {
+					return ErrTLSPinMismatch
+				}
+				sum := sha256.Sum256(cs.PeerCertificates[0].Raw)
+				if _, ok := pins[hex.EncodeToString(sum[:])]; !ok {
+					return ErrTLSPinMismatch
+				}
+				if next != nil {
+					return next(cs)
+				}
+				return nil
+			}
+			t.TLSClientConfig = cfg
+			return nil
+		})
+	}
+}
+
+// WithNetwork forces the API connection onto "tcp4" or "tcp6", or restores
+// the dual-stack default with "tcp". Proxy settings and the client timeout
+// are unaffected; when a proxy is used the chosen network applies to the
+// connection to the proxy. The transport's own DialContext, and with it any
+// dial timeout, is kept and only handed the chosen network.
+func WithNetwork(network string) Option {
+	return func(c *QRNGClient) {
+		c.transportOpts = append(c.transportOpts, func(t *http.Transport) error {
+			switch network {
+			case "tcp", "tcp4", "tcp6":
+			default:
+				return ErrInvalidNetwork
+			}
+
+			dial := t.DialContext
+			if dial == nil {
+				dialer := &net.Dialer{
+					Timeout:   dialTimeout,
+					KeepAlive: dialKeepAlive,
+				}
+				dial = dialer.DialContext
+			}
+			t.DialContext = func(ctx context.Context, _, addr string) (net.Conn, error) {
+				return dial(ctx, network, addr)
+			}
+			return nil
+		})
+	}
+}
+
+// WithConnectionPool tunes keep-alive pooling for the API host: maxIdle caps
+// idle connections overall, maxIdlePerHost caps them per host (Go's default
+// of 2 is low for a busy buffered client), and idleTimeout is how long an
+// idle connection is kept before closing. Zero values keep the transport's
+// current setting.
+func WithConnectionPool(maxIdle, maxIdlePerHost int, idleTimeout time.Duration) Option {
+	return func(c *QRNGClient) {
+		c.transportOpts = append(c.transportOpts, func(t *http.Transport) error {
+			if maxIdle < 0 || maxIdlePerHost < 0 || idleTimeout < 0 {
+				return errors.New("connection pool settings must not be negative")
+			}
+
+			if maxIdle > 0 {
+				t.MaxIdleConns = maxIdle
+			}
+			if maxIdlePerHost > 0 {
+				t.MaxIdleConnsPerHost = maxIdlePerHost
+			}
+			if idleTimeout > 0 {
+				t.IdleConnTimeout = idleTimeout
+			}
+			return nil
+		})
+	}
+}
+
+// WithForceHTTP1 disables HTTP/2 so every request uses HTTP/1.1. Use it as a
+// workaround when a corporate proxy or middlebox mishandles HTTP/2 streams
+// to the API, typically showing up as intermittent stream or GOAWAY errors;
+// otherwise HTTP/2's connection multiplexing is preferable.
+func WithForceHTTP1() Option {
+	return func(c *QRNGClient) {
+		c.transportOpts = append(c.transportOpts, func(t *http.Transport) error {
+			t.ForceAttemptHTTP2 = false
+			t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+			if t.TLSClientConfig != nil {
+				cfg := t.TLSClientConfig.Clone()
+				// Clone shares the NextProtos backing array, so filter into a
+				// new slice rather than deleting in place
+				var protos []string
+				for _, p := range cfg.NextProtos {
+					if p != "h2" {
+						protos = append(protos, p)
+					}
+				}
+				cfg.NextProtos = protos
+				t.TLSClientConfig = cfg
+			}
+			return nil
+		})
+	}
+}
+
+// errorTransport fails every request, used when a requested transport
+// option cannot be honoured.
+type errorTransport struct {
+	err error
+}
+
+func (t errorTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, &transportConfigError{t.err}
+}
+
+// transportConfigError marks a failure caused by the client's transport
+// configuration, which no retry can fix
+type transportConfigError struct {
+	err error
+}
+
+func (e *transportConfigError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportConfigError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,222 @@
+package qrng_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestWithTLSPin(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[7],"success":true}`)
+	}))
+	defer server.Close()
+
+	sum := sha256.Sum256(server.Certificate().Raw)
+	pin := hex.EncodeToString(sum[:])
+
+	t.Run("matching pin", func(t *testing.T) {
+		client := qrng.NewClient(
+			qrng.WithTLSPin(pin),
+			qrng.WithTransport(server.Client().Transport),
+		)
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("mismatched pin", func(t *testing.T) {
+		client := qrng.NewClient(
+			qrng.WithTransport(server.Client().Transport),
+			qrng.WithTLSPin(strings.Repeat("00:", 31)+"00"),
+		)
+		client.APIEndpoint = server.URL
+
+		_, err := client.GetRandomUint8(1)
+		if !errors.Is(err, qrng.ErrTLSPinMismatch) {
+			t.Errorf("Expected ErrTLSPinMismatch, got %v", err)
+		}
+	})
+
+	t.Run("invalid pins", func(t *testing.T) {
+		for _, pins := range [][]string{nil, {"00"}, {pin[:63] + "g"}, {pin, pin + "00"}} {
+			client := qrng.NewClient(
+				qrng.WithTransport(server.Client().Transport),
+				qrng.WithTLSPin(pins...),
+			)
+			client.APIEndpoint = server.URL
+
+			if _, err := client.GetRandomUint8(1); !errors.Is(err, qrng.ErrInvalidTLSPin) {
+				t.Errorf("Expected ErrInvalidTLSPin for %q, got %v", pins, err)
+			}
+		}
+	})
+
+	t.Run("unsupported transport", func(t *testing.T) {
+		rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			return nil, errors.New("should not be called")
+		})
+		client := qrng.NewClient(qrng.WithTransport(rt), qrng.WithTLSPin(pin))
+		client.APIEndpoint = server.URL
+
+		_, err := client.GetRandomUint8(1)
+		if !errors.Is(err, qrng.ErrUnsupportedTransport) {
+			t.Errorf("Expected ErrUnsupportedTransport, got %v", err)
+		}
+	})
+}
+
+func TestWithNetwork(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":1,"data":[7],"success":true}`)
+	defer server.Close()
+
+	t.Run("tcp4", func(t *testing.T) {
+		client := qrng.NewClient(qrng.WithNetwork("tcp4"))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("tcp6 cannot reach ipv4 listener", func(t *testing.T) {
+		client := qrng.NewClient(qrng.WithNetwork("tcp6"))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err == nil {
+			t.Error("Expected dial error over tcp6")
+		}
+	})
+
+	t.Run("keeps the transport's dialer", func(t *testing.T) {
+		var networks []string
+		var dialer net.Dialer
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				networks = append(networks, network)
+				return dialer.DialContext(ctx, network, addr)
+			},
+		}
+		client := qrng.NewClient(qrng.WithTransport(transport), qrng.WithNetwork("tcp4"))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(networks) != 1 || networks[0] != "tcp4" {
+			t.Errorf("Expected the custom dialer to be called with tcp4, got %v", networks)
+		}
+	})
+
+	t.Run("invalid network", func(t *testing.T) {
+		client := qrng.NewClient(qrng.WithNetwork("udp"))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); !errors.Is(err, qrng.ErrInvalidNetwork) {
+			t.Errorf("Expected ErrInvalidNetwork, got %v", err)
+		}
+	})
+}
+
+func TestWithConnectionPool(t *testing.T) {
+	t.Run("configures transport", func(t *testing.T) {
+		client := qrng.NewClient(qrng.WithConnectionPool(50, 10, time.Minute))
+
+		tr, ok := client.HTTPClient.Transport.(*http.Transport)
+		if !ok {
+			t.Fatalf("Expected *http.Transport, got %T", client.HTTPClient.Transport)
+		}
+		if tr.MaxIdleConns != 50 || tr.MaxIdleConnsPerHost != 10 || tr.IdleConnTimeout != time.Minute {
+			t.Errorf("Unexpected pool settings %d/%d/%v", tr.MaxIdleConns, tr.MaxIdleConnsPerHost, tr.IdleConnTimeout)
+		}
+		if http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost == 10 {
+			t.Error("DefaultTransport must not be modified")
+		}
+	})
+
+	t.Run("zero keeps the transport's settings", func(t *testing.T) {
+		transport := &http.Transport{MaxIdleConns: 7, MaxIdleConnsPerHost: 5, IdleConnTimeout: time.Second}
+		client := qrng.NewClient(qrng.WithTransport(transport), qrng.WithConnectionPool(0, 0, 0))
+
+		tr, ok := client.HTTPClient.Transport.(*http.Transport)
+		if !ok {
+			t.Fatalf("Expected *http.Transport, got %T", client.HTTPClient.Transport)
+		}
+		if tr.MaxIdleConns != 7 || tr.MaxIdleConnsPerHost != 5 || tr.IdleConnTimeout != time.Second {
+			t.Errorf("Unexpected pool settings %d/%d/%v", tr.MaxIdleConns, tr.MaxIdleConnsPerHost, tr.IdleConnTimeout)
+		}
+	})
+
+	t.Run("composes with other transport options", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":1,"data":[7],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithNetwork("tcp4"), qrng.WithConnectionPool(0, 4, 0))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("negative settings", func(t *testing.T) {
+		client := qrng.NewClient(qrng.WithConnectionPool(-1, 0, 0))
+		if _, err := client.GetRandomUint8(1); err == nil {
+			t.Error("Expected error for negative settings")
+		}
+	})
+}
+
+func TestWithForceHTTP1(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"type":"uint8","length":1,"data":[%d],"success":true}`, r.ProtoMajor)
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	sum := sha256.Sum256(server.Certificate().Raw)
+	pin := hex.EncodeToString(sum[:])
+
+	for _, tc := range []struct {
+		name  string
+		opts  []qrng.Option
+		proto uint8
+	}{
+		{"default negotiates HTTP/2", nil, 2},
+		{"forced HTTP/1.1", []qrng.Option{qrng.WithForceHTTP1()}, 1},
+		{"composes with TLS pinning", []qrng.Option{qrng.WithTLSPin(pin), qrng.WithForceHTTP1()}, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append([]qrng.Option{qrng.WithTransport(server.Client().Transport)}, tc.opts...)
+			client := qrng.NewClient(opts...)
+			client.APIEndpoint = server.URL
+
+			data, err := client.GetRandomUint8(1)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if data[0] != tc.proto {
+				t.Errorf("Expected HTTP/%d, got HTTP/%d", tc.proto, data[0])
+			}
+		})
+	}
+}
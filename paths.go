@@ -0,0 +1,188 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// GetRandomWalk returns the positions of a 1-D random walk started at 0
+// after each of steps moves of ±stepSize. Each direction comes from one
+// quantum bit, with all bits fetched in one batch.
+func (c *QRNGClient) GetRandomWalk(steps int, stepSize float64) ([]float64, error) {
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+	if math.IsNaN(stepSize) || math.IsInf(stepSize, 0) {
+		return nil, errors.New("stepSize must be finite")
+	}
+
+	bits, err := c.randomBitsN(context.Background(), steps)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]float64, steps)
+	pos := 0.0
+	for i, b := range bits {
+		if b == 1 {
+			pos += stepSize
+		} else {
+			pos -= stepSize
+		}
+		positions[i] = pos
+	}
+	return positions, nil
+}
+
+// GetRandomWalk2D returns the positions of a walk on the square lattice
+// started at the origin, moving stepSize up, down, left or right with equal
+// probability on each step. Each direction takes two quantum bits.
+func (c *QRNGClient) GetRandomWalk2D(steps int, stepSize float64) ([][2]float64, error) {
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+	if steps > math.MaxInt/2 {
+		return nil, ErrRangeTooLarge
+	}
+	if math.IsNaN(stepSize) || math.IsInf(stepSize, 0) {
+		return nil, errors.New("stepSize must be finite")
+	}
+
+	bits, err := c.randomBitsN(context.Background(), 2*steps)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([][2]float64, steps)
+	var x, y float64
+	for i := range points {
+		switch bits[2*i]<<1 | bits[2*i+1] {
+		case 0:
+			x += stepSize
+		case 1:
+			x -= stepSize
+		case 2:
+			y += stepSize
+		case 3:
+			y -= stepSize
+		}
+		points[i] = [2]float64{x, y}
+	}
+	return points, nil
+}
+
+// GetRandomOUPath returns an Ornstein–Uhlenbeck path started at x0, giving
+// the value after each of steps time steps of length dt. It uses the
+// Euler–Maruyama scheme
+//
+//	x[t+1] = x[t] + theta*(mu - x[t])*dt + sigma*sqrt(dt)*z[t]
+//
+// where the z[t] are quantum standard normals fetched in one batch. theta is
+// the rate of reversion towards mu; keep theta*dt well below 1 for the
+// discretisation to stay accurate.
+func (c *QRNGClient) GetRandomOUPath(steps int, x0, theta, mu, sigma, dt float64) ([]float64, error) {
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+	if !(dt > 0) || math.IsInf(dt, 1) {
+		return nil, errors.New("dt must be positive and finite")
+	}
+	if !(sigma >= 0) || math.IsInf(sigma, 1) {
+		return nil, errors.New("sigma must be non-negative and finite")
+	}
+	for _, v := range []float64{x0, theta, mu} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.New("x0, theta and mu must be finite")
+		}
+	}
+
+	z, err := c.normals(context.Background(), steps)
+	if err != nil {
+		return nil, err
+	}
+
+	scale := sigma * math.Sqrt(dt)
+	path := make([]float64, steps)
+	x := x0
+	for i := range path {
+		x += theta*(mu-x)*dt + scale*z[i]
+		path[i] = x
+	}
+	return path, nil
+}
+
+// GetRandomGBMPath returns a geometric Brownian motion price path started at
+// s0, giving the price after each of steps time steps of length dt. mu is
+// the drift and sigma the volatility, both per unit time. Steps use the
+// exact log-normal solution
+//
+//	s[t+1] = s[t] * exp((mu - sigma²/2)*dt + sigma*sqrt(dt)*z[t])
+//
+// so prices stay positive for any dt. The quantum normals z[t] are fetched
+// in one batch.
+func (c *QRNGClient) GetRandomGBMPath(steps int, s0, mu, sigma, dt float64) ([]float64, error) {
+	if steps < 1 {
+		return nil, errors.New("steps must be at least 1")
+	}
+	if !(s0 > 0) || math.IsInf(s0, 1) {
+		return nil, errors.New("s0 must be positive and finite")
+	}
+	if !(dt > 0) || math.IsInf(dt, 1) {
+		return nil, errors.New("dt must be positive and finite")
+	}
+	if !(sigma >= 0) || math.IsInf(sigma, 1) {
+		return nil, errors.New("sigma must be non-negative and finite")
+	}
+	if math.IsNaN(mu) || math.IsInf(mu, 0) {
+		return nil, errors.New("mu must be finite")
+	}
+
+	z, err := c.normals(context.Background(), steps)
+	if err != nil {
+		return nil, err
+	}
+
+	drift := (mu - sigma*sigma/2) * dt
+	scale := sigma * math.Sqrt(dt)
+	path := make([]float64, steps)
+	s := s0
+	for i := range path {
+		s *= math.Exp(drift + scale*z[i])
+		path[i] = s
+	}
+	return path, nil
+}
+
+// GetRandomAR1Series returns n values of the stationary AR(1) process
+//
+//	x[t] = phi*x[t-1] + sigma*z[t]
+//
+// where the z[t] are quantum standard normals fetched in one batch. phi
+// sets the lag-one autocorrelation and must satisfy |phi| < 1. The first
+// value is drawn from the stationary distribution, a normal with variance
+// sigma²/(1-phi²), rather than started at zero, so the series has no
+// burn-in transient.
+func (c *QRNGClient) GetRandomAR1Series(n int, phi, sigma float64) ([]float64, error) {
+	if n < 1 {
+		return nil, errors.New("n must be at least 1")
+	}
+	if !(math.Abs(phi) < 1) {
+		return nil, errors.New("phi must be between -1 and 1 exclusive")
+	}
+	if !(sigma >= 0) || math.IsInf(sigma, 1) {
+		return nil, errors.New("sigma must be non-negative and finite")
+	}
+
+	z, err := c.normals(context.Background(), n)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]float64, n)
+	series[0] = sigma / math.Sqrt(1-phi*phi) * z[0]
+	for t := 1; t < n; t++ {
+		series[t] = phi*series[t-1] + sigma*z[t]
+	}
+	return series, nil
+}
@@ -0,0 +1,166 @@
+package qrng_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestGetRandomWalk(t *testing.T) {
+	t.Run("one dimension", func(t *testing.T) {
+		// 0xB4 = 10110100
+		server := newStaticServer(t, `{"type":"uint8","length":1,"data":[180],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		walk, err := client.GetRandomWalk(6, 0.5)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(walk) != "[0.5 0 0.5 1 0.5 1]" {
+			t.Errorf("Expected [0.5 0 0.5 1 0.5 1], got %v", walk)
+		}
+	})
+
+	t.Run("two dimensions", func(t *testing.T) {
+		// 0x1B = 00 01 10 11: right, left, up, down
+		server := newStaticServer(t, `{"type":"uint8","length":1,"data":[27],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		walk, err := client.GetRandomWalk2D(4, 2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(walk) != "[[2 0] [0 0] [0 2] [0 0]]" {
+			t.Errorf("Expected [[2 0] [0 0] [0 2] [0 0]], got %v", walk)
+		}
+	})
+
+	t.Run("invalid steps", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomWalk(0, 1); err == nil {
+			t.Error("Expected error for 0 steps")
+		}
+		if _, err := client.GetRandomWalk2D(0, 1); err == nil {
+			t.Error("Expected error for 0 steps")
+		}
+	})
+}
+
+func TestGetRandomOUPath(t *testing.T) {
+	t.Run("euler steps", func(t *testing.T) {
+		// normals 2, 0 then 0, 2 (radius 2 at angles 0 and 0.25 turns)
+		server := uniformSequenceServer(t, 1-math.Exp(-2), 0, 1-math.Exp(-2), 0.25)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		path, err := client.GetRandomOUPath(3, 10, 0.5, 0, 1, 0.25)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		// x += 0.5*(0-x)*0.25 + 0.5*z
+		x1 := 10 - 1.25 + 1
+		x2 := x1 - x1*0.125
+		x3 := x2 - x2*0.125
+		for i, want := range []float64{x1, x2, x3} {
+			if math.Abs(path[i]-want) > 1e-9 {
+				t.Errorf("Step %d: expected %v, got %v", i, want, path[i])
+			}
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		client := qrng.NewClient()
+		for name, args := range map[string][6]float64{
+			"zero steps":     {0, 0, 1, 0, 1, 0.1},
+			"zero dt":        {5, 0, 1, 0, 1, 0},
+			"negative sigma": {5, 0, 1, 0, -1, 0.1},
+			"infinite x0":    {5, math.Inf(1), 1, 0, 1, 0.1},
+		} {
+			if _, err := client.GetRandomOUPath(int(args[0]), args[1], args[2], args[3], args[4], args[5]); err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+		}
+	})
+}
+
+func TestGetRandomGBMPath(t *testing.T) {
+	t.Run("log-normal steps", func(t *testing.T) {
+		// normals 2 then 0
+		server := uniformSequenceServer(t, 1-math.Exp(-2), 0)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		path, err := client.GetRandomGBMPath(2, 100, 0.1, 0.2, 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		// drift (0.1 - 0.02) = 0.08 per step, shock 0.2*z
+		s1 := 100 * math.Exp(0.08+0.4)
+		s2 := s1 * math.Exp(0.08)
+		if math.Abs(path[0]-s1) > 1e-9 || math.Abs(path[1]-s2) > 1e-9 {
+			t.Errorf("Expected [%v %v], got %v", s1, s2, path)
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		client := qrng.NewClient()
+		for name, args := range map[string][5]float64{
+			"zero steps":     {0, 100, 0, 0.2, 1},
+			"zero s0":        {5, 0, 0, 0.2, 1},
+			"negative sigma": {5, 100, 0, -0.2, 1},
+			"zero dt":        {5, 100, 0, 0.2, 0},
+		} {
+			if _, err := client.GetRandomGBMPath(int(args[0]), args[1], args[2], args[3], args[4]); err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+		}
+	})
+}
+
+func TestGetRandomAR1Series(t *testing.T) {
+	t.Run("autoregressive steps", func(t *testing.T) {
+		// normals 2 then 0
+		server := uniformSequenceServer(t, 1-math.Exp(-2), 0)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		series, err := client.GetRandomAR1Series(2, 0.5, 1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		// the first value has the stationary scale 1/sqrt(1-0.25)
+		x0 := 2 / math.Sqrt(0.75)
+		if math.Abs(series[0]-x0) > 1e-9 || math.Abs(series[1]-0.5*x0) > 1e-9 {
+			t.Errorf("Expected [%v %v], got %v", x0, 0.5*x0, series)
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		client := qrng.NewClient()
+		for name, args := range map[string][3]float64{
+			"zero n":         {0, 0.5, 1},
+			"unit phi":       {5, 1, 1},
+			"negative phi":   {5, -1.5, 1},
+			"NaN phi":        {5, math.NaN(), 1},
+			"negative sigma": {5, 0.5, -1},
+		} {
+			if _, err := client.GetRandomAR1Series(int(args[0]), args[1], args[2]); err == nil {
+				t.Errorf("%s: expected error", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,63 @@
+// Package prometheus exports QRNG client activity as Prometheus metrics. It
+// is a separate module, so the core client and its consumers do not depend
+// on the Prometheus libraries.
+package prometheus
+
+import (
+	qrng "github.com/albertnieto/anu-qrng-go"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// RegisterMetrics registers request, byte, latency, retry and error
+// collectors with reg and feeds them from client's OnRequest hook. Every
+// HTTP attempt is counted, retries included; values served from the buffered
+// pool are not. Registering two clients with the same registry fails with a
+// duplicate registration error; wrap reg with prom.WrapRegistererWith to
+// label each client.
+func RegisterMetrics(reg prom.Registerer, client *qrng.QRNGClient) error {
+	requests := prom.NewCounterVec(prom.CounterOpts{
+		Name: "qrng_requests_total",
+		Help: "HTTP attempts made against the QRNG API, by data type.",
+	}, []string{"type"})
+	errs := prom.NewCounterVec(prom.CounterOpts{
+		Name: "qrng_request_errors_total",
+		Help: "HTTP attempts against the QRNG API that failed, by data type.",
+	}, []string{"type"})
+	retries := prom.NewCounterVec(prom.CounterOpts{
+		Name: "qrng_retries_total",
+		Help: "HTTP attempts that were retries of a failed attempt, by data type.",
+	}, []string{"type"})
+	bytes := prom.NewCounter(prom.CounterOpts{
+		Name: "qrng_bytes_total",
+		Help: "Bytes of random data received from the QRNG API.",
+	})
+	latency := prom.NewHistogramVec(prom.HistogramOpts{
+		Name:    "qrng_request_duration_seconds",
+		Help:    "Duration of HTTP attempts against the QRNG API, by data type.",
+		Buckets: prom.DefBuckets,
+	}, []string{"type"})
+
+	collectors := []prom.Collector{requests, errs, retries, bytes, latency}
+	for i, col := range collectors {
+		if err := reg.Register(col); err != nil {
+			// leave reg as it was rather than half-registered
+			for _, done := range collectors[:i] {
+				reg.Unregister(done)
+			}
+			return err
+		}
+	}
+
+	client.OnRequest(func(ev qrng.RequestEvent) {
+		requests.WithLabelValues(ev.DataType).Inc()
+		latency.WithLabelValues(ev.DataType).Observe(ev.Duration.Seconds())
+		if ev.Attempt > 0 {
+			retries.WithLabelValues(ev.DataType).Inc()
+		}
+		if ev.Err != nil {
+			errs.WithLabelValues(ev.DataType).Inc()
+		}
+		bytes.Add(float64(ev.Bytes))
+	})
+	return nil
+}
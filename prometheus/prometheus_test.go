@@ -0,0 +1,73 @@
+package prometheus_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+	qrngprom "github.com/albertnieto/anu-qrng-go/prometheus"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+func TestRegisterMetrics(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, `{"type":"uint8","length":4,"data":[1,2,3,4],"success":true}`)
+	}))
+	defer server.Close()
+
+	client := qrng.NewClient(qrng.WithRetries(1), qrng.WithBackoff(qrng.ConstantBackoff{}))
+	client.APIEndpoint = server.URL
+
+	reg := prom.NewRegistry()
+	if err := qrngprom.RegisterMetrics(reg, client); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := client.GetRandomUint8(4); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got := make(map[string]float64)
+	for _, mf := range families {
+		for _, m := range mf.GetMetric() {
+			switch {
+			case m.GetCounter() != nil:
+				got[mf.GetName()] += m.GetCounter().GetValue()
+			case m.GetHistogram() != nil:
+				got[mf.GetName()] += float64(m.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+
+	expected := map[string]float64{
+		"qrng_requests_total":           2,
+		"qrng_request_errors_total":     1,
+		"qrng_retries_total":            1,
+		"qrng_bytes_total":              4,
+		"qrng_request_duration_seconds": 2,
+	}
+	for name, want := range expected {
+		if got[name] != want {
+			t.Errorf("%s: expected %v, got %v", name, want, got[name])
+		}
+	}
+
+	t.Run("duplicate registration", func(t *testing.T) {
+		if err := qrngprom.RegisterMetrics(reg, qrng.NewClient()); err == nil {
+			t.Error("Expected duplicate registration error")
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Provenance records where the entropy behind one result came from, for
+// audit trails. It is returned by the WithProvenance variants of the main
+// methods.
+type Provenance struct {
+	// Endpoint is the URL of the last API request made for the result, or
+	// "" if every value came from the buffered pool.
+	Endpoint string
+
+	// Seeds lists the distinct generator seeds reported by the responses
+	// used, in the order first seen.
+	Seeds []string
+
+	// Time is when the result was complete.
+	Time time.Time
+
+	// Requests counts the successful API responses used and Retries the
+	// failed HTTP attempts, each of which was retried or ended the call.
+	Requests int
+	Retries  int
+
+	// Pooled is set when some values were served from bytes buffered before
+	// the call, by an earlier pool refill or a seed file, whose own
+	// provenance is not tracked.
+	Pooled bool
+
+	// Fallback is set when values did not come from the live quantum API.
+	// The client has no pseudo-random fallback, so this only happens for a
+	// client created by NewReplayClient, which serves a recorded log.
+	Fallback bool
+}
+
+type provenanceRecorder struct {
+	mu sync.Mutex
+	p  Provenance
+}
+
+func withProvenance[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) (T, Provenance, error) {
+	rec := &provenanceRecorder{}
+	v, err := fn(context.WithValue(ctx, provenanceContextKey, rec))
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	p := rec.p
+	p.Time = time.Now()
+	return v, p, err
+}
+
+func provenanceFromContext(ctx context.Context) *provenanceRecorder {
+	rec, _ := ctx.Value(provenanceContextKey).(*provenanceRecorder)
+	return rec
+}
+
+// attempt records the outcome of one HTTP attempt against endpoint
+func (r *provenanceRecorder) attempt(endpoint string, qr *QRNGResponse, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		r.p.Retries++
+		return
+	}
+	r.p.Requests++
+	r.p.Endpoint = endpoint
+	if endpoint == replayEndpoint {
+		r.p.Fallback = true
+	}
+	if qr.Seed != "" && !slices.Contains(r.p.Seeds, qr.Seed) {
+		r.p.Seeds = append(r.p.Seeds, qr.Seed)
+	}
+}
+
+func (r *provenanceRecorder) pooled() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.p.Pooled = true
+}
+
+// GetRandomBytesWithProvenance is GetRandomBytes that also reports the
+// provenance of the bytes
+func (c *QRNGClient) GetRandomBytesWithProvenance(numBytes int) ([]byte, Provenance, error) {
+	if numBytes < 1 {
+		return nil, Provenance{}, errors.New("numBytes must be at least 1")
+	}
+	return withProvenance(context.Background(), func(ctx context.Context) ([]byte, error) {
+		return c.readBytes(ctx, numBytes)
+	})
+}
+
+// GetRandomUint16WithProvenance is GetRandomUint16 that also reports the
+// provenance of the values
+func (c *QRNGClient) GetRandomUint16WithProvenance(numShorts int) ([]uint16, Provenance, error) {
+	return withProvenance(context.Background(), func(ctx context.Context) ([]uint16, error) {
+		return c.randomUint16(ctx, numShorts)
+	})
+}
+
+// GetRandomNumbersWithProvenance is GetRandomNumbers that also reports the
+// provenance of the numbers
+func (c *QRNGClient) GetRandomNumbersWithProvenance(min, max, count int) ([]int, Provenance, error) {
+	if count < 1 {
+		return nil, Provenance{}, errors.New("count must be at least 1")
+	}
+	return withProvenance(context.Background(), func(ctx context.Context) ([]int, error) {
+		return c.randomInts(ctx, min, max, count)
+	})
+}
@@ -0,0 +1,78 @@
+package qrng_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestProvenance(t *testing.T) {
+	t.Run("live request", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":4,"data":[1,2,3,4],"success":true,"seed":"abc"}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		data, p, err := client.GetRandomBytesWithProvenance(4)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(data) != "[1 2 3 4]" {
+			t.Errorf("Expected [1 2 3 4], got %v", data)
+		}
+		if p.Endpoint != server.URL || p.Requests != 1 || p.Retries != 0 || p.Pooled || p.Fallback {
+			t.Errorf("Unexpected provenance %+v", p)
+		}
+		if fmt.Sprint(p.Seeds) != "[abc]" || p.Time.IsZero() {
+			t.Errorf("Unexpected provenance %+v", p)
+		}
+	})
+
+	t.Run("counts retries", func(t *testing.T) {
+		server, _ := newFlakyServer(t, 2, http.StatusServiceUnavailable)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithRetries(3), qrng.WithBackoff(&recordingBackoff{}))
+		client.APIEndpoint = server.URL
+
+		if _, p, err := client.GetRandomNumbersWithProvenance(0, 1, 1); err != nil || p.Requests != 1 || p.Retries != 2 {
+			t.Errorf("Expected 1 request after 2 retries, got %+v (%v)", p, err)
+		}
+	})
+
+	t.Run("pooled values", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.BufferSize = 16
+
+		if _, p, err := client.GetRandomBytesWithProvenance(4); err != nil || p.Pooled || p.Requests != 1 {
+			t.Errorf("Expected a fresh fetch, got %+v (%v)", p, err)
+		}
+		if _, p, err := client.GetRandomBytesWithProvenance(4); err != nil || !p.Pooled || p.Requests != 0 || p.Endpoint != "" {
+			t.Errorf("Expected pooled values, got %+v (%v)", p, err)
+		}
+	})
+
+	t.Run("replay is a fallback", func(t *testing.T) {
+		client, err := qrng.NewReplayClient(strings.NewReader(`{"type":"uint16","length":2,"data":[7,8]}` + "\n"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		values, p, err := client.GetRandomUint16WithProvenance(2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(values) != "[7 8]" || !p.Fallback || p.Requests != 1 {
+			t.Errorf("Expected replayed values flagged as fallback, got %v %+v", values, p)
+		}
+	})
+}
@@ -1,23 +1,28 @@
 package qrng
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
+	"math/bits"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 const (
-	maxUint8Length  = 1024
-	maxUint16Length = 1024
-	maxBits         = maxUint8Length * 8
-	defaultTimeout  = 10 * time.Second
+	maxUint8Length = 1024
+	defaultTimeout = 10 * time.Second
 )
 
 var (
@@ -26,6 +31,8 @@ var (
 	ErrMissingAPIKey    = errors.New("API key required for this endpoint")
 	ErrInvalidHexType   = errors.New("invalid hex type, must be hex8 or hex16")
 	ErrInvalidBlockSize = errors.New("block size must be between 1-10")
+	ErrEmptyData        = errors.New("api reported success but returned no data")
+	ErrTypeMismatch     = errors.New("api returned a different type than requested")
 )
 
 type QRNGClient struct {
@@ -33,22 +40,103 @@ type QRNGClient struct {
 	HTTPClient  *http.Client
 	APIKey      string
 	useAPIKey   bool
+
+	// EndpointResolver, if set, chooses the URL for each data type ("uint8",
+	// "uint16", "hex8", "hex16" or a raw GetRandomTyped type), for API
+	// layouts that serve types from different paths. Returning "" falls back
+	// to APIEndpoint.
+	EndpointResolver func(dataType string) string
+
+	// ExtraParams is an advanced escape hatch: its values are added to the
+	// query string of every request, for API parameters the typed methods
+	// do not model yet. Per-call parameters and the mandatory length and
+	// type always take precedence.
+	ExtraParams url.Values
+
+	// Unmarshal decodes response bodies into a QRNGResponse. It defaults to
+	// json.Unmarshal and can be replaced to use a faster JSON library or to
+	// strip a proxy's envelope before decoding.
+	Unmarshal func(data []byte, v any) error
+
+	// PostProcess, if set, transforms every batch of uint8 values after it
+	// has been fetched and validated and before it reaches the buffered pool
+	// or the caller, for example to XOR the quantum bytes with a local CSPRNG
+	// when a policy requires combining sources. It must return as many bytes
+	// as it was given. A careless transform can destroy entropy (mapping
+	// everything to zero is a valid function), so only use vetted mixing
+	// steps. Typed requests such as uint16 and hex are not post-processed.
+	PostProcess func(data []byte) ([]byte, error)
+
+	// BufferSize enables a local pool of uint8 values when greater than zero.
+	// Byte-oriented methods draw from the pool and refill it in batches of at
+	// least BufferSize (capped at the API maximum per request).
+	BufferSize int
+
+	// MinFetchSize sets a floor for the length of each pool refill, capped
+	// at the API maximum, so small requests against a small BufferSize or a
+	// seed-file pool still fetch in useful batches and leave the surplus
+	// buffered. It has no effect while the pool is disabled, where every
+	// request fetches exactly what it asks for.
+	MinFetchSize int
+
+	// Timeouts sets default deadlines per kind of operation for calls whose
+	// context has none; see Timeouts.
+	Timeouts Timeouts
+
+	mu   sync.Mutex
+	pool []uint8
+
+	seedFile string
+	seedErr  error
+
+	transportOpts      []func(*http.Transport) error
+	recorder           *recorder
+	wireLog            *wireLog
+	maxRetries         int
+	backoff            BackoffStrategy
+	qualityAlpha       float64
+	quota              *quota
+	duplicateDetection bool
+	skipTypeCheck      bool
+	accept             string
+	parsers            map[string]ResponseParser
+
+	flight singleflight.Group
+
+	metaMu    sync.Mutex
+	lastSeed  string
+	lastMeta  ResponseMetadata
+	haveMeta  bool
+	onRefresh func(oldSeed, newSeed string)
+	rateLimit rateLimitStatus
+
+	hooksMu      sync.Mutex
+	requestHooks []func(RequestEvent)
+	latencies    *latencyRing
+
+	discoveredMax atomic.Int64
+
+	keepAliveInterval time.Duration
+	keepAlive         keepAlive
+	lastActivity      atomic.Int64
 }
 
 // NewClient creates client for the legacy API (no key required)
-func NewClient() *QRNGClient {
-	return &QRNGClient{
+func NewClient(opts ...Option) *QRNGClient {
+	c := &QRNGClient{
 		APIEndpoint: "https://qrng.anu.edu.au/API/jsonI.php",
 		HTTPClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
 		useAPIKey: false,
 	}
+	c.applyOptions(opts)
+	return c
 }
 
 // NewClientWithAPIKey creates client for the new authenticated API
-func NewClientWithAPIKey(apiKey string) *QRNGClient {
-	return &QRNGClient{
+func NewClientWithAPIKey(apiKey string, opts ...Option) *QRNGClient {
+	c := &QRNGClient{
 		APIEndpoint: "https://api.quantumnumbers.anu.edu.au",
 		APIKey:      apiKey,
 		HTTPClient: &http.Client{
@@ -56,6 +144,79 @@ func NewClientWithAPIKey(apiKey string) *QRNGClient {
 		},
 		useAPIKey: true,
 	}
+	c.applyOptions(opts)
+	return c
+}
+
+// Clone returns a client with the same configuration as c, modified by opts,
+// for example WithAPIKey to derive a per-tenant client.
+//
+// The clone shares with c: the HTTPClient and therefore its connection pool
+// (unless opts include a transport option, which gives the clone its own
+// copy), the recorder and wire log, the backoff strategy, the Unmarshal,
+// PostProcess and EndpointResolver functions, the OnRefresh and OnRequest
+// callbacks registered so far and any WithQuota budget. It copies: the
+// endpoint, API key, ExtraParams, BufferSize, MinFetchSize, Timeouts, the
+// Accept header and parsers, any DiscoverLimits result, and the retry,
+// quality-check, duplicate-detection and response-type validation settings.
+// It starts with its own empty buffered pool and latency history, no seed
+// file, no keep-alive pinger unless opts include WithKeepAlive, and its own
+// seed history, rate-limit status and in-flight request tracking.
+func (c *QRNGClient) Clone(opts ...Option) *QRNGClient {
+	c.metaMu.Lock()
+	onRefresh := c.onRefresh
+	c.metaMu.Unlock()
+
+	c.hooksMu.Lock()
+	requestHooks := c.requestHooks
+	c.hooksMu.Unlock()
+
+	clone := &QRNGClient{
+		APIEndpoint:        c.APIEndpoint,
+		HTTPClient:         c.HTTPClient,
+		APIKey:             c.APIKey,
+		useAPIKey:          c.useAPIKey,
+		EndpointResolver:   c.EndpointResolver,
+		Unmarshal:          c.Unmarshal,
+		PostProcess:        c.PostProcess,
+		BufferSize:         c.BufferSize,
+		MinFetchSize:       c.MinFetchSize,
+		Timeouts:           c.Timeouts,
+		recorder:           c.recorder,
+		wireLog:            c.wireLog,
+		maxRetries:         c.maxRetries,
+		backoff:            c.backoff,
+		qualityAlpha:       c.qualityAlpha,
+		quota:              c.quota,
+		duplicateDetection: c.duplicateDetection,
+		skipTypeCheck:      c.skipTypeCheck,
+		accept:             c.accept,
+		parsers:            c.parsers,
+		onRefresh:          onRefresh,
+		requestHooks:       requestHooks,
+	}
+	if c.ExtraParams != nil {
+		clone.ExtraParams = make(url.Values, len(c.ExtraParams))
+		for k, v := range c.ExtraParams {
+			clone.ExtraParams[k] = append([]string(nil), v...)
+		}
+	}
+	if c.latencies != nil {
+		clone.latencies = newLatencyRing(len(c.latencies.buf))
+	}
+	clone.discoveredMax.Store(c.discoveredMax.Load())
+	clone.applyOptions(opts)
+	return clone
+}
+
+// endpointFor returns the URL that serves dataType
+func (c *QRNGClient) endpointFor(dataType string) string {
+	if c.EndpointResolver != nil {
+		if endpoint := c.EndpointResolver(dataType); endpoint != "" {
+			return endpoint
+		}
+	}
+	return c.APIEndpoint
 }
 
 // Update requiresAPIKey check
@@ -75,25 +236,104 @@ type QRNGResponse struct {
 	Info           []string `json:"info"`
 }
 
+// apiError is a request the API rejected with success:false
+type apiError struct {
+	message string
+	info    []string
+}
+
+func (e *apiError) Error() string {
+	if e.message != "" {
+		return "api error: " + e.message
+	}
+	return "api request failed"
+}
+
+// insufficientDataError is a successful response carrying fewer values than
+// requested
+type insufficientDataError struct {
+	expected, got int
+}
+
+func (e *insufficientDataError) Error() string {
+	return fmt.Sprintf("insufficient data: expected %d, got %d", e.expected, e.got)
+}
+
 func (c *QRNGClient) GetRandomBits(numBits int) ([]int, error) {
-	if numBits < 1 || numBits > maxBits {
-		return nil, fmt.Errorf("numBits must be between 1 and %d", maxBits)
+	if limit := c.maxLength() * 8; numBits < 1 || numBits > limit {
+		return nil, fmt.Errorf("numBits must be between 1 and %d", limit)
 	}
 
 	requiredBytes := (numBits + 7) / 8
-	qr, err := c.doRequest(requiredBytes, "uint8", 0)
+	data, err := c.fetchUint8(context.Background(), requiredBytes)
 	if err != nil {
 		return nil, err
 	}
 
-	return extractBits(qr.Data, numBits), nil
+	return extractBits(data, numBits), nil
+}
+
+// GetRandomBitsWithParity returns numBits bits whose sum is even when
+// evenParity is true and odd otherwise. The first numBits-1 bits are random
+// and the last is a parity bit chosen to enforce the requested parity, so a
+// single bit is fixed and needs no fetch.
+func (c *QRNGClient) GetRandomBitsWithParity(numBits int, evenParity bool) ([]int, error) {
+	if limit := c.maxLength() * 8; numBits < 1 || numBits > limit {
+		return nil, fmt.Errorf("numBits must be between 1 and %d", limit)
+	}
+
+	bits := make([]int, 0, numBits)
+	if numBits > 1 {
+		random, err := c.GetRandomBits(numBits - 1)
+		if err != nil {
+			return nil, err
+		}
+		bits = append(bits, random...)
+	}
+
+	parity := 0
+	for _, b := range bits {
+		parity ^= b
+	}
+	if !evenParity {
+		parity ^= 1
+	}
+	return append(bits, parity), nil
+}
+
+// BitsFromBytes returns the first numBits bits of data, MSB-first, one int
+// (0 or 1) per bit. If data holds fewer bits, all of them are returned.
+func BitsFromBytes(data []byte, numBits int) []int {
+	if numBits < 1 {
+		return []int{}
+	}
+	return extractBits(data, numBits)
 }
 
-func extractBits(data []int, numBits int) []int {
+// PackedBitsFromBytes returns the first numBits bits of data packed MSB-first
+// into (numBits+7)/8 bytes, zeroing unused bits of the final byte and
+// zero-padding if data is shorter
+func PackedBitsFromBytes(data []byte, numBits int) []byte {
+	if numBits < 1 {
+		return []byte{}
+	}
+	return packBits(data, numBits)
+}
+
+// randomBitsN returns n random bits, fetching as many requests as needed
+func (c *QRNGClient) randomBitsN(ctx context.Context, n int) ([]int, error) {
+	data, err := c.readBytes(ctx, (n+7)/8)
+	if err != nil {
+		return nil, err
+	}
+	return extractBits(data, n), nil
+}
+
+func extractBits(data []uint8, numBits int) []int {
 	bits := make([]int, 0, numBits)
 	for _, byteVal := range data {
 		for i := 7; i >= 0; i-- {
-			bits = append(bits, (byteVal>>i)&1)
+			bits = append(bits, int(byteVal>>i)&1)
 			if len(bits) == numBits {
 				return bits
 			}
@@ -102,17 +342,173 @@ func extractBits(data []int, numBits int) []int {
 	return bits
 }
 
+// GetRandomBitsPacked returns numBits random bits packed MSB-first into
+// (numBits+7)/8 bytes. Unused low-order bits of the final byte are zero.
+func (c *QRNGClient) GetRandomBitsPacked(numBits int) ([]byte, error) {
+	if limit := c.maxLength() * 8; numBits < 1 || numBits > limit {
+		return nil, fmt.Errorf("numBits must be between 1 and %d", limit)
+	}
+
+	data, err := c.fetchUint8(context.Background(), (numBits+7)/8)
+	if err != nil {
+		return nil, err
+	}
+
+	return packBits(data, numBits), nil
+}
+
+// GetRandomBitsBigInt returns a non-negative integer of numBits random bits.
+// With topBitSet the most significant of them is forced to one, so the
+// result has a bit length of exactly numBits, as wanted for cryptographic
+// candidate values; only numBits-1 bits are then random.
+func (c *QRNGClient) GetRandomBitsBigInt(numBits int, topBitSet bool) (*big.Int, error) {
+	if limit := c.maxLength() * 8; numBits < 1 || numBits > limit {
+		return nil, fmt.Errorf("numBits must be between 1 and %d", limit)
+	}
+
+	data, err := c.fetchUint8(context.Background(), (numBits+7)/8)
+	if err != nil {
+		return nil, err
+	}
+
+	// the surplus bits are the high bits of the first byte
+	data[0] &= 0xff >> (len(data)*8 - numBits)
+	n := new(big.Int).SetBytes(data)
+	if topBitSet {
+		n.SetBit(n, numBits-1, 1)
+	}
+	return n, nil
+}
+
+// FlipRandomBits inverts numFlips distinct bits of data in place, chosen
+// uniformly without replacement, and returns their positions in ascending
+// order. Position p is bit 7-p%8 of data[p/8], so bits are numbered
+// MSB-first as in GetRandomBits. data is left untouched if the fetch fails,
+// and numFlips of zero needs no fetch.
+func (c *QRNGClient) FlipRandomBits(data []byte, numFlips int) ([]int, error) {
+	if numFlips < 0 || numFlips > len(data)*8 {
+		return nil, fmt.Errorf("numFlips must be between 0 and %d", len(data)*8)
+	}
+	if numFlips == 0 {
+		return []int{}, nil
+	}
+
+	positions, err := c.sampleDistinct(context.Background(), len(data)*8, numFlips)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(positions)
+	for _, p := range positions {
+		data[p/8] ^= 0x80 >> (p % 8)
+	}
+	return positions, nil
+}
+
+func packBits(data []uint8, numBits int) []byte {
+	packed := make([]byte, (numBits+7)/8)
+	copy(packed, data)
+	if rem := numBits % 8; rem != 0 {
+		packed[len(packed)-1] &= 0xff << (8 - rem)
+	}
+	return packed
+}
+
 func (c *QRNGClient) GetRandomUint8(numBytes int) ([]uint8, error) {
-	if numBytes < 1 || numBytes > maxUint8Length {
-		return nil, fmt.Errorf("numBytes must be between 1 and %d", maxUint8Length)
+	if limit := c.maxLength(); numBytes < 1 || numBytes > limit {
+		return nil, fmt.Errorf("numBytes must be between 1 and %d", limit)
+	}
+
+	return c.fetchUint8(context.Background(), numBytes)
+}
+
+// GetRandomUint8Context is like GetRandomUint8 but honours ctx for
+// cancellation and per-request settings such as ContextWithAPIKey
+func (c *QRNGClient) GetRandomUint8Context(ctx context.Context, numBytes int) ([]uint8, error) {
+	if limit := c.maxLength(); numBytes < 1 || numBytes > limit {
+		return nil, fmt.Errorf("numBytes must be between 1 and %d", limit)
+	}
+
+	return c.fetchUint8(ctx, numBytes)
+}
+
+// GetRandomBytes returns numBytes random bytes, splitting the fetch into as
+// many API requests as needed
+func (c *QRNGClient) GetRandomBytes(numBytes int) ([]byte, error) {
+	if numBytes < 1 {
+		return nil, errors.New("numBytes must be at least 1")
 	}
 
-	qr, err := c.doRequest(numBytes, "uint8", 0)
+	return c.readBytes(context.Background(), numBytes)
+}
+
+// GetRandomBytesCombined returns numBytes quantum bytes XORed with numBytes
+// read from extra, such as crypto/rand.Reader, so the output is at least as
+// unpredictable as the stronger of the two sources provided they are
+// independent. extra is read first, and a reader that cannot supply
+// numBytes fails the call before any quantum bytes are fetched.
+func (c *QRNGClient) GetRandomBytesCombined(numBytes int, extra io.Reader) ([]byte, error) {
+	if numBytes < 1 {
+		return nil, errors.New("numBytes must be at least 1")
+	}
+	if extra == nil {
+		return nil, errors.New("extra must not be nil")
+	}
+
+	mixed := make([]byte, numBytes)
+	if n, err := io.ReadFull(extra, mixed); err != nil {
+		return nil, fmt.Errorf("extra source supplied %d of %d bytes: %w", n, numBytes, err)
+	}
+
+	data, err := c.readBytes(context.Background(), numBytes)
 	if err != nil {
 		return nil, err
 	}
+	for i, b := range data {
+		mixed[i] ^= b
+	}
+	return mixed, nil
+}
+
+// GetRandom16 returns 16 random bytes, sized for AES-128 keys
+func (c *QRNGClient) GetRandom16() ([16]byte, error) {
+	var key [16]byte
+	data, err := c.GetRandomBytes(len(key))
+	if err != nil {
+		return key, err
+	}
+	copy(key[:], data)
+	return key, nil
+}
+
+// GetRandom32 returns 32 random bytes, sized for AES-256 keys and Ed25519 seeds
+func (c *QRNGClient) GetRandom32() ([32]byte, error) {
+	var key [32]byte
+	data, err := c.GetRandomBytes(len(key))
+	if err != nil {
+		return key, err
+	}
+	copy(key[:], data)
+	return key, nil
+}
+
+func (c *QRNGClient) readBytes(ctx context.Context, numBytes int) ([]byte, error) {
+	if numBytes > c.maxLength() {
+		var cancel context.CancelFunc
+		ctx, cancel = withTimeout(ctx, c.Timeouts.Bulk)
+		defer cancel()
+	}
 
-	return convertUint8(qr.Data), nil
+	result := make([]byte, 0, numBytes)
+	for len(result) < numBytes {
+		chunk := min(numBytes-len(result), c.maxLength())
+		data, err := c.fetchUint8(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, data[:chunk]...)
+	}
+	return result, nil
 }
 
 func convertUint8(data []int) []uint8 {
@@ -124,11 +520,15 @@ func convertUint8(data []int) []uint8 {
 }
 
 func (c *QRNGClient) GetRandomUint16(numShorts int) ([]uint16, error) {
-	if numShorts < 1 || numShorts > maxUint16Length {
-		return nil, fmt.Errorf("numShorts must be between 1 and %d", maxUint16Length)
+	return c.randomUint16(context.Background(), numShorts)
+}
+
+func (c *QRNGClient) randomUint16(ctx context.Context, numShorts int) ([]uint16, error) {
+	if limit := c.maxLength(); numShorts < 1 || numShorts > limit {
+		return nil, fmt.Errorf("numShorts must be between 1 and %d", limit)
 	}
 
-	qr, err := c.doRequest(numShorts, "uint16", 0)
+	qr, err := c.doRequest(ctx, numShorts, "uint16", 0)
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +545,16 @@ func convertUint16(data []int) []uint16 {
 }
 
 func (c *QRNGClient) GetRandomHex(blockCount, blockSize int, hexType string) ([]string, error) {
+	return c.randomHex(blockCount, blockSize, hexType, false)
+}
+
+// GetRandomHexUpper is like GetRandomHex but formats the blocks with
+// uppercase digits A-F
+func (c *QRNGClient) GetRandomHexUpper(blockCount, blockSize int, hexType string) ([]string, error) {
+	return c.randomHex(blockCount, blockSize, hexType, true)
+}
+
+func (c *QRNGClient) randomHex(blockCount, blockSize int, hexType string, upper bool) ([]string, error) {
 	if hexType != "hex8" && hexType != "hex16" {
 		return nil, ErrInvalidHexType
 	}
@@ -153,19 +563,24 @@ func (c *QRNGClient) GetRandomHex(blockCount, blockSize int, hexType string) ([]
 		return nil, ErrInvalidBlockSize
 	}
 
-	qr, err := c.doRequest(blockCount, hexType, blockSize)
+	qr, err := c.doRequest(context.Background(), blockCount, hexType, blockSize)
 	if err != nil {
 		return nil, err
 	}
 
-	return formatHex(qr.Data, hexType, blockSize), nil
+	return formatHex(qr.Data, hexType, blockSize, upper), nil
 }
 
-func formatHex(data []int, hexType string, blockSize int) []string {
+func formatHex(data []int, hexType string, blockSize int, upper bool) []string {
+	verb := "x"
+	if upper {
+		verb = "X"
+	}
+
 	result := make([]string, len(data))
-	format := "%04x"
+	format := "%04" + verb
 	if hexType == "hex8" {
-		format = fmt.Sprintf("%%0%dx", blockSize*2)
+		format = fmt.Sprintf("%%0%d%s", blockSize*2, verb)
 	}
 
 	for i, v := range data {
@@ -174,37 +589,72 @@ func formatHex(data []int, hexType string, blockSize int) []string {
 	return result
 }
 
+// GetRandomNumber returns an unbiased integer in [min, max] by rejection
+// sampling. Each draw uses only as many bits as the range needs, and bits
+// left over from a fetched batch carry over to the next draw after a
+// rejection, so the API is only called again once they run out.
 func (c *QRNGClient) GetRandomNumber(min, max int) (int, error) {
-	if min > max {
-		return 0, ErrInvalidRange
+	r, err := newIntRange(min, max)
+	if err != nil {
+		return 0, err
 	}
 
-	rangeSize := max - min + 1
-	if rangeSize <= 0 {
-		return 0, ErrRangeTooLarge
-	}
+	value, _, err := c.sampleNumber(r, nil)
+	return value, err
+}
 
-	bitSize := 1
-	for (1 << bitSize) < rangeSize {
-		bitSize++
+// GetRandomNumberTraced is like GetRandomNumber, drawing exactly as it does,
+// but also reports how the value was derived: consumed holds every byte
+// fetched, in order, including bytes whose bits went to rejected draws and
+// any unused bits of the final batch; attempts counts the rejection-sampling
+// draws, several of which may come from one fetched batch.
+func (c *QRNGClient) GetRandomNumberTraced(min, max int) (value int, consumed []byte, attempts int, err error) {
+	r, err := newIntRange(min, max)
+	if err != nil {
+		return 0, nil, 0, err
 	}
 
-	if bitSize > maxBits {
-		return 0, fmt.Errorf("%w: maximum supported bits is %d", ErrRangeTooLarge, maxBits)
-	}
+	value, attempts, err = c.sampleNumber(r, func(fetched []byte) {
+		consumed = append(consumed, fetched...)
+	})
+	return value, consumed, attempts, err
+}
 
-	requiredBytes := (bitSize + 7) / 8
-	mask := (1 << bitSize) - 1
+// sampleNumber draws one value from r for GetRandomNumber and
+// GetRandomNumberTraced, calling fetched, if set, with every batch of bytes
+// it fetches
+func (c *QRNGClient) sampleNumber(r intRange, fetched func([]byte)) (value, attempts int, err error) {
+	bitSize := bits.Len(uint(r.mask))
 
+	// reservoir holds unused bits, least significant first, so the first
+	// draw from a batch is its low bitSize bits read as a big-endian integer
+	var reservoir []int
 	for {
-		randomBytes, err := c.GetRandomUint8(requiredBytes)
-		if err != nil {
-			return 0, err
+		if len(reservoir) < bitSize {
+			randomBytes, err := c.GetRandomUint8(r.bytes)
+			if err != nil {
+				return 0, attempts, err
+			}
+			randomBytes = randomBytes[:r.bytes]
+			if fetched != nil {
+				fetched(randomBytes)
+			}
+			for i := r.bytes - 1; i >= 0; i-- {
+				for j := 0; j < 8; j++ {
+					reservoir = append(reservoir, int(randomBytes[i]>>j)&1)
+				}
+			}
+		}
+
+		attempts++
+		randInt := 0
+		for i, b := range reservoir[:bitSize] {
+			randInt |= b << i
 		}
+		reservoir = reservoir[bitSize:]
 
-		randInt := bytesToInt(randomBytes) & mask
-		if randInt < rangeSize {
-			return min + randInt, nil
+		if randInt < r.size {
+			return r.min + randInt, attempts, nil
 		}
 	}
 }
@@ -217,32 +667,150 @@ func bytesToInt(bytes []uint8) int {
 	return result
 }
 
-func (c *QRNGClient) doRequest(length int, dataType string, blockSize int) (*QRNGResponse, error) {
-	if c.requiresAPIKey() && c.APIKey == "" {
+// GetRandomTyped issues a raw request for the given data type, passing
+// extraParams through to the API. The mandatory length and type parameters
+// always take precedence over values supplied in extraParams.
+func (c *QRNGClient) GetRandomTyped(length int, dataType string, extraParams url.Values) (*QRNGResponse, error) {
+	if limit := c.maxLength(); length < 1 || length > limit {
+		return nil, fmt.Errorf("length must be between 1 and %d", limit)
+	}
+
+	return c.doRequestParams(context.Background(), length, dataType, extraParams)
+}
+
+// CheckAvailability confirms the API is reachable and, for the authenticated
+// API, that the key is accepted. ANU exposes no zero-cost or HEAD-capable
+// endpoint, so this issues a length=1 uint8 request that bypasses the buffer
+// and consumes one value of quota per call; rate-limit health probes
+// accordingly.
+func (c *QRNGClient) CheckAvailability(ctx context.Context) error {
+	ctx, cancel := withTimeout(ctx, c.Timeouts.Probe)
+	defer cancel()
+
+	_, err := c.doRequest(ctx, 1, "uint8", 0)
+	return err
+}
+
+func (c *QRNGClient) doRequest(ctx context.Context, length int, dataType string, blockSize int) (*QRNGResponse, error) {
+	var extra url.Values
+	if strings.HasPrefix(dataType, "hex") && blockSize > 0 {
+		extra = url.Values{"size": {strconv.Itoa(blockSize)}}
+	}
+
+	return c.doRequestParams(ctx, length, dataType, extra)
+}
+
+func (c *QRNGClient) doRequestParams(ctx context.Context, length int, dataType string, extra url.Values) (*QRNGResponse, error) {
+	apiKey := c.APIKey
+	if key, ok := apiKeyFromContext(ctx); ok {
+		apiKey = key
+	}
+
+	if c.requiresAPIKey() && apiKey == "" {
 		return nil, ErrMissingAPIKey
 	}
 
-	params := url.Values{
-		"length": {strconv.Itoa(length)},
-		"type":   {dataType},
+	if c.seedErr != nil {
+		return nil, c.seedErr
 	}
 
-	if strings.HasPrefix(dataType, "hex") && blockSize > 0 {
-		params.Add("size", strconv.Itoa(blockSize))
+	ctx, cancel := withTimeout(ctx, c.Timeouts.Request)
+	defer cancel()
+
+	params := url.Values{}
+	for k, v := range c.ExtraParams {
+		params[k] = append([]string(nil), v...)
+	}
+	for k, v := range extra {
+		params[k] = append([]string(nil), v...)
 	}
+	params.Set("length", strconv.Itoa(length))
+	params.Set("type", dataType)
+
+	var qr *QRNGResponse
+	var err error
+	if token, ok := idempotencyTokenFromContext(ctx); ok {
+		qr, err = c.doShared(ctx, token, apiKey, length, dataType, params)
+	} else {
+		qr, err = c.doWithRetry(ctx, apiKey, length, dataType, params)
+	}
+
+	if err == nil && c.duplicateDetection {
+		err = c.crossCheck(ctx, apiKey, length, dataType, params, qr)
+	}
+	if err != nil {
+		return nil, redactKey(err, apiKey)
+	}
+	return qr, nil
+}
+
+func (c *QRNGClient) doWithRetry(ctx context.Context, apiKey string, length int, dataType string, params url.Values) (*QRNGResponse, error) {
+	for attempt := 0; ; attempt++ {
+		// an attempt refused by the client-side quota never reaches the
+		// network, so it is not reported as one
+		if c.quota != nil {
+			if err := c.quota.reserve(); err != nil {
+				return nil, err
+			}
+		}
+
+		start := time.Now()
+		qr, err := c.doAttempt(ctx, apiKey, length, dataType, params)
+
+		ev := RequestEvent{
+			DataType: dataType,
+			Length:   length,
+			Attempt:  attempt,
+			Duration: time.Since(start),
+			Err:      redactKey(err, apiKey),
+		}
+		ev.RequestID, _ = requestIDFromContext(ctx)
+		if c.latencies != nil {
+			c.latencies.add(ev.Duration)
+		}
+		if err == nil {
+			ev.Bytes = len(qr.Data) * valueBits(dataType, params) / 8
+		}
+		c.emitRequest(ev)
+		if rec := provenanceFromContext(ctx); rec != nil {
+			rec.attempt(c.endpointFor(dataType), qr, err)
+		}
+
+		if err == nil || attempt >= c.maxRetries || !isRetryable(err) {
+			return qr, err
+		}
+
+		if err := sleepContext(ctx, c.backoff.NextDelay(attempt+1)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *QRNGClient) doAttempt(ctx context.Context, apiKey string, length int, dataType string, params url.Values) (*QRNGResponse, error) {
+	c.touch()
 
 	req, err := http.NewRequestWithContext(
-		context.Background(),
+		ctx,
 		http.MethodGet,
-		c.APIEndpoint+"?"+params.Encode(),
+		c.endpointFor(dataType)+"?"+params.Encode(),
 		nil,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("request creation failed: %w", err)
 	}
+	// net/http rejects these on every attempt, so fail before sending
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" || req.URL.Host == "" {
+		return nil, fmt.Errorf("request creation failed: endpoint %q is not an absolute http or https URL", req.URL.Redacted())
+	}
 
 	if c.requiresAPIKey() {
-		req.Header.Add("x-api-key", c.APIKey)
+		req.Header.Set("x-api-key", apiKey)
+	}
+	if c.accept != "" {
+		req.Header.Set("Accept", c.accept)
+	}
+	if id, ok := requestIDFromContext(ctx); ok && id != "" {
+		req.Header.Set(requestIDHeader, id)
 	}
 
 	client := c.HTTPClient
@@ -252,37 +820,84 @@ func (c *QRNGClient) doRequest(length int, dataType string, blockSize int) (*QRN
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		if c.wireLog != nil {
+			c.wireLog.exchange(req, nil, nil, redactKey(err, apiKey))
+		}
+		err = fmt.Errorf("request failed: %w", err)
+		redactBody(err, apiKey)
+		var statusErr *StatusError
+		if ctx.Err() != nil || isPermanentTransportError(err) ||
+			errors.As(err, &statusErr) && !isRetryableStatus(statusErr.StatusCode) {
+			return nil, err
+		}
+		return nil, &retryableError{err}
 	}
 	defer resp.Body.Close()
+	c.observeRateLimit(resp)
+
+	if c.wireLog != nil {
+		var raw bytes.Buffer
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.TeeReader(resp.Body, &raw), resp.Body}
+		defer func() { c.wireLog.exchange(req, resp, raw.Bytes(), nil) }()
+	}
 
 	if resp.StatusCode != http.StatusOK {
-		errBody, errRead := io.ReadAll(resp.Body)
-		if errRead != nil {
-			return nil, fmt.Errorf("unexpected status code %d: error reading body: %w", resp.StatusCode, errRead)
+		err := statusError(resp)
+		redactBody(err, apiKey)
+		if isRetryableStatus(resp.StatusCode) {
+			return nil, &retryableError{err}
 		}
-		return nil, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, errBody)
+		return nil, err
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed reading response: %w", err)
+		err = fmt.Errorf("failed reading response: %w", err)
+		if ctx.Err() == nil && isTruncatedRead(err) {
+			return nil, &retryableError{err}
+		}
+		return nil, err
 	}
 
-	var qr QRNGResponse
-	if err := json.Unmarshal(body, &qr); err != nil {
-		return nil, fmt.Errorf("json parse error: %w", err)
+	qr, err := c.parseResponse(resp.Header.Get("Content-Type"), body)
+	if err != nil {
+		redactBody(err, apiKey)
+		if isTruncatedBody(err, body) {
+			return nil, &retryableError{err}
+		}
+		return nil, err
 	}
 
 	if !qr.Success {
-		if qr.Error != "" {
-			return nil, fmt.Errorf("api error: %s", qr.Error)
-		}
-		return nil, errors.New("api request failed")
+		return nil, &apiError{message: qr.Error, info: qr.Info}
 	}
 
+	if !c.skipTypeCheck && qr.Type != "" && qr.Type != dataType {
+		return nil, fmt.Errorf("%w: requested %q, got %q", ErrTypeMismatch, dataType, qr.Type)
+	}
+	if len(qr.Data) == 0 {
+		return nil, fmt.Errorf("%w: expected %d values", ErrEmptyData, length)
+	}
 	if len(qr.Data) < length {
-		return nil, fmt.Errorf("insufficient data: expected %d, got %d", length, len(qr.Data))
+		return nil, &insufficientDataError{expected: length, got: len(qr.Data)}
+	}
+
+	if c.qualityAlpha > 0 {
+		if err := monobitCheck(qr.Data, valueBits(dataType, params), c.qualityAlpha); err != nil {
+			return nil, &retryableError{err}
+		}
+	}
+
+	c.observeMetadata(&qr)
+
+	// duplicate-detection witnesses are discarded, so replay must not serve them
+	if c.recorder != nil && ctx.Value(witnessContextKey) == nil {
+		if err := c.recorder.record(dataType, length, qr.Data); err != nil {
+			return nil, fmt.Errorf("recording failed: %w", err)
+		}
 	}
 
 	return &qr, nil
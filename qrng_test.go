@@ -1,10 +1,16 @@
 package qrng_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -41,6 +47,281 @@ func TestGetRandomBits(t *testing.T) {
 	})
 }
 
+func TestGetRandomBitsPacked(t *testing.T) {
+	t.Run("final byte zero-padded", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"type":"uint8","length":2,"data":[255,255],"success":true}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		packed, err := client.GetRandomBitsPacked(11)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := []byte{0xff, 0xe0}
+		if fmt.Sprint(packed) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, got %v", expected, packed)
+		}
+	})
+
+	t.Run("invalid numBits", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomBitsPacked(0); err == nil {
+			t.Error("Expected error for invalid numBits")
+		}
+	})
+}
+
+func TestGetRandomBitsWithParity(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":1,"data":[176],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	tests := []struct {
+		name     string
+		numBits  int
+		even     bool
+		expected []int
+	}{
+		{"even", 5, true, []int{1, 0, 1, 1, 1}},
+		{"odd", 5, false, []int{1, 0, 1, 1, 0}},
+		{"single even bit", 1, true, []int{0}},
+		{"single odd bit", 1, false, []int{1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bits, err := client.GetRandomBitsWithParity(tt.numBits, tt.even)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if fmt.Sprint(bits) != fmt.Sprint(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, bits)
+			}
+		})
+	}
+
+	t.Run("invalid numBits", func(t *testing.T) {
+		if _, err := client.GetRandomBitsWithParity(0, true); err == nil {
+			t.Error("Expected error for invalid numBits")
+		}
+	})
+}
+
+func TestGetRandomBitsBigInt(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":2,"data":[5,1],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	tests := []struct {
+		name     string
+		numBits  int
+		topBit   bool
+		expected int64
+	}{
+		// 0x0501 masked to 10 bits is 0x101
+		{"masked", 10, false, 0x101},
+		{"top bit set", 10, true, 0x301},
+		{"whole bytes", 16, false, 0x0501},
+		{"single bit", 1, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := client.GetRandomBitsBigInt(tt.numBits, tt.topBit)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if n.Int64() != tt.expected {
+				t.Errorf("Expected %#x, got %#x", tt.expected, n)
+			}
+			if tt.topBit && n.BitLen() != tt.numBits {
+				t.Errorf("Expected bit length %d, got %d", tt.numBits, n.BitLen())
+			}
+		})
+	}
+
+	t.Run("invalid numBits", func(t *testing.T) {
+		if _, err := client.GetRandomBitsBigInt(0, false); err == nil {
+			t.Error("Expected error for invalid numBits")
+		}
+	})
+}
+
+func TestFlipRandomBits(t *testing.T) {
+	t.Run("flips sampled positions", func(t *testing.T) {
+		// the draws pick positions 15, then 4 (3 past the first step), then 2
+		server := newStaticServer(t, `{"type":"uint8","length":3,"data":[15,3,0],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		data := []byte{0xff, 0x00}
+		positions, err := client.FlipRandomBits(data, 3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(positions) != "[2 4 15]" {
+			t.Errorf("Expected [2 4 15], got %v", positions)
+		}
+		if data[0] != 0xd7 || data[1] != 0x01 {
+			t.Errorf("Expected [0xd7 0x01], got %#x", data)
+		}
+	})
+
+	t.Run("positions are distinct", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		data := make([]byte, 2)
+		positions, err := client.FlipRandomBits(data, 16)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(positions) != 16 || data[0] != 0xff || data[1] != 0xff {
+			t.Errorf("Expected every bit flipped once, got %v and %#x", positions, data)
+		}
+	})
+
+	t.Run("invalid numFlips", func(t *testing.T) {
+		client := qrng.NewClient()
+		for _, n := range []int{-1, 17} {
+			if _, err := client.FlipRandomBits(make([]byte, 2), n); err == nil {
+				t.Errorf("Expected error for numFlips %d", n)
+			}
+		}
+		if positions, err := client.FlipRandomBits(nil, 0); err != nil || len(positions) != 0 {
+			t.Errorf("Expected no flips, got %v (%v)", positions, err)
+		}
+	})
+}
+
+func TestGetRandomBytesCombined(t *testing.T) {
+	t.Run("XORs both sources", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":3,"data":[255,15,0],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		data, err := client.GetRandomBytesCombined(3, bytes.NewReader([]byte{0x0f, 0x0f, 0xaa}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(data) != "[240 0 170]" {
+			t.Errorf("Expected [240 0 170], got %v", data)
+		}
+	})
+
+	t.Run("short extra source", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		_, err := client.GetRandomBytesCombined(4, bytes.NewReader([]byte{1, 2}))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+		}
+		if calls != 0 {
+			t.Errorf("Expected no fetch, got %d", calls)
+		}
+		if _, err := client.GetRandomBytesCombined(4, nil); err == nil {
+			t.Error("Expected error for nil extra")
+		}
+	})
+}
+
+func TestEmptyData(t *testing.T) {
+	for _, body := range []string{
+		`{"success":true,"data":[]}`,
+		`{"success":true}`,
+	} {
+		t.Run(body, func(t *testing.T) {
+			server := newStaticServer(t, body)
+			defer server.Close()
+
+			client := qrng.NewClient()
+			client.APIEndpoint = server.URL
+
+			if _, err := client.GetRandomUint8(4); !errors.Is(err, qrng.ErrEmptyData) {
+				t.Errorf("Expected ErrEmptyData, got %v", err)
+			}
+			if _, err := client.GetRandomHex(2, 1, "hex8"); !errors.Is(err, qrng.ErrEmptyData) {
+				t.Errorf("Expected ErrEmptyData, got %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateResponseType(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint16","length":2,"data":[513,1027],"success":true}`)
+	defer server.Close()
+
+	t.Run("mismatch", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(2); !errors.Is(err, qrng.ErrTypeMismatch) {
+			t.Errorf("Expected ErrTypeMismatch, got %v", err)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		client := qrng.NewClient(qrng.WithValidateResponseType(false))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint16(2); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := client.Clone().GetRandomUint8(2); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func TestBitsFromBytes(t *testing.T) {
+	data := []byte{0xa5, 0xff}
+
+	t.Run("unpacked", func(t *testing.T) {
+		bits := qrng.BitsFromBytes(data, 10)
+		expected := []int{1, 0, 1, 0, 0, 1, 0, 1, 1, 1}
+		if fmt.Sprint(bits) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, got %v", expected, bits)
+		}
+		if len(qrng.BitsFromBytes(data, 100)) != 16 {
+			t.Error("Expected all available bits when numBits exceeds data")
+		}
+	})
+
+	t.Run("packed", func(t *testing.T) {
+		packed := qrng.PackedBitsFromBytes(data, 12)
+		expected := []byte{0xa5, 0xf0}
+		if fmt.Sprint(packed) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, got %v", expected, packed)
+		}
+	})
+
+	t.Run("non-positive numBits", func(t *testing.T) {
+		if len(qrng.BitsFromBytes(data, 0)) != 0 || len(qrng.PackedBitsFromBytes(data, -1)) != 0 {
+			t.Error("Expected empty results")
+		}
+	})
+}
+
 func TestGetRandomUint8(t *testing.T) {
 	t.Run("successful response", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -63,6 +344,60 @@ func TestGetRandomUint8(t *testing.T) {
 	})
 }
 
+func TestGetRandomBytes(t *testing.T) {
+	t.Run("spans multiple requests", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		data, err := client.GetRandomBytes(1500)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(data) != 1500 {
+			t.Errorf("Expected 1500 bytes, got %d", len(data))
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 requests, got %d", calls)
+		}
+	})
+
+	t.Run("invalid numBytes", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomBytes(0); err == nil {
+			t.Error("Expected error for invalid numBytes")
+		}
+	})
+}
+
+func TestGetRandomArrays(t *testing.T) {
+	var calls int32
+	server := newCountingServer(t, &calls)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	key16, err := client.GetRandom16()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key16[15] != 15 {
+		t.Errorf("Expected last byte 15, got %d", key16[15])
+	}
+
+	key32, err := client.GetRandom32()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key32[31] != 31 {
+		t.Errorf("Expected last byte 31, got %d", key32[31])
+	}
+}
+
 func TestGetRandomUint16(t *testing.T) {
 	t.Run("successful response", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -105,6 +440,26 @@ func TestGetRandomHex(t *testing.T) {
 			t.Errorf("Expected %v, got %v", expected, hexVals)
 		}
 	})
+
+	t.Run("uppercase", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"type":"hex8","length":2,"data":[171,12],"success":true}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClientWithAPIKey("---")
+		client.APIEndpoint = server.URL
+
+		hexVals, err := client.GetRandomHexUpper(2, 1, "hex8")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := []string{"AB", "0C"}
+		if fmt.Sprint(hexVals) != fmt.Sprint(expected) {
+			t.Errorf("Expected %v, got %v", expected, hexVals)
+		}
+	})
 }
 
 func TestGetRandomNumber(t *testing.T) {
@@ -126,6 +481,31 @@ func TestGetRandomNumber(t *testing.T) {
 		}
 	})
 
+	t.Run("rejection reuses surplus bits", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			// 0x17 = 00 010 111: the low 3 bits (7) are rejected for a range
+			// of 5, the next 3 bits (2) are accepted
+			fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[23],"success":true}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		num, err := client.GetRandomNumber(10, 14)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if num != 12 {
+			t.Errorf("Expected 12, got %d", num)
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 request, got %d", calls)
+		}
+	})
+
 	t.Run("invalid range", func(t *testing.T) {
 		client := qrng.NewClient()
 		_, err := client.GetRandomNumber(10, 5)
@@ -135,6 +515,40 @@ func TestGetRandomNumber(t *testing.T) {
 	})
 }
 
+func TestGetRandomNumberTraced(t *testing.T) {
+	t.Run("reports rejected draws", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[238],"success":true}`)
+				return
+			}
+			fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[3],"success":true}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		// range size 10 -> 4-bit draws: both nibbles of 238 are 14 and
+		// rejected, then the low nibble of 3 is accepted
+		value, consumed, attempts, err := client.GetRandomNumberTraced(1, 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if value != 4 || attempts != 3 || fmt.Sprint(consumed) != "[238 3]" {
+			t.Errorf("Unexpected trace value=%d consumed=%v attempts=%d", value, consumed, attempts)
+		}
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, _, _, err := client.GetRandomNumberTraced(3, 1); err != qrng.ErrInvalidRange {
+			t.Errorf("Expected ErrInvalidRange, got %v", err)
+		}
+	})
+}
+
 func TestClientConfiguration(t *testing.T) {
 	t.Run("custom HTTP client", func(t *testing.T) {
 		client := qrng.NewClient()
@@ -196,3 +610,244 @@ func TestErrorHandling(t *testing.T) {
 		}
 	})
 }
+
+func TestGetRandomTyped(t *testing.T) {
+	t.Run("extra params passed through", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("size") != "6" || q.Get("length") != "2" || q.Get("type") != "hex8" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `{"type":"hex8","length":2,"data":[1,2],"success":true}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		extra := url.Values{"size": {"6"}, "length": {"99"}}
+		qr, err := client.GetRandomTyped(2, "hex8", extra)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(qr.Data) != "[1 2]" {
+			t.Errorf("Expected [1 2], got %v", qr.Data)
+		}
+	})
+
+	t.Run("client extra params merged", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if q.Get("beta") != "1" || q.Get("size") != "3" || q.Get("type") != "hex8" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `{"type":"hex8","length":1,"data":[1],"success":true}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.ExtraParams = url.Values{"beta": {"1"}, "size": {"9"}, "type": {"uint16"}}
+
+		if _, err := client.GetRandomHex(1, 3, "hex8"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("invalid length", func(t *testing.T) {
+		client := qrng.NewClient()
+		_, err := client.GetRandomTyped(0, "uint8", nil)
+		if err == nil {
+			t.Error("Expected error for invalid length")
+		}
+	})
+}
+
+func TestCheckAvailability(t *testing.T) {
+	t.Run("reachable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("length") != "1" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[1],"success":true}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.BufferSize = 64
+
+		if err := client.CheckAvailability(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClientWithAPIKey("bad")
+		client.APIEndpoint = server.URL
+
+		if err := client.CheckAvailability(context.Background()); err == nil {
+			t.Error("Expected error for rejected key")
+		}
+	})
+}
+
+func TestCustomUnmarshal(t *testing.T) {
+	t.Run("envelope stripped", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"payload":{"type":"uint8","length":1,"data":[42],"success":true}}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.Unmarshal = func(data []byte, v any) error {
+			var envelope struct {
+				Payload json.RawMessage `json:"payload"`
+			}
+			if err := json.Unmarshal(data, &envelope); err != nil {
+				return err
+			}
+			return json.Unmarshal(envelope.Payload, v)
+		}
+
+		data, err := client.GetRandomUint8(1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if data[0] != 42 {
+			t.Errorf("Expected 42, got %d", data[0])
+		}
+	})
+
+	t.Run("unmarshal error surfaced", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{}`)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.Unmarshal = func([]byte, any) error { return errors.New("boom") }
+
+		if _, err := client.GetRandomUint8(1); err == nil {
+			t.Error("Expected unmarshal error")
+		}
+	})
+}
+
+func TestClone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"type":"uint8","length":1,"data":[%d],"success":true}`, len(r.Header.Get("x-api-key")))
+	}))
+	defer server.Close()
+
+	base := qrng.NewClientWithAPIKey("base")
+	base.APIEndpoint = server.URL
+
+	t.Run("overrides API key and shares HTTP client", func(t *testing.T) {
+		tenant := base.Clone(qrng.WithAPIKey("tenant-a"))
+
+		if tenant.HTTPClient != base.HTTPClient {
+			t.Error("Expected clone to share the HTTP client")
+		}
+		if tenant.APIEndpoint != base.APIEndpoint || base.APIKey != "base" {
+			t.Error("Clone must copy config without modifying the original")
+		}
+
+		data, err := tenant.GetRandomUint8(1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if data[0] != uint8(len("tenant-a")) {
+			t.Errorf("Expected tenant key to be sent, server saw length %d", data[0])
+		}
+	})
+
+	t.Run("transport options do not touch the original", func(t *testing.T) {
+		transport := base.HTTPClient.Transport
+		tenant := base.Clone(qrng.WithNetwork("tcp4"))
+
+		if tenant.HTTPClient == base.HTTPClient {
+			t.Error("Expected clone with transport option to own its HTTP client")
+		}
+		if base.HTTPClient.Transport != transport {
+			t.Error("Original transport was modified")
+		}
+		if _, err := tenant.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("copies extra params", func(t *testing.T) {
+		parent := base.Clone()
+		parent.ExtraParams = url.Values{"tag": {"parent"}}
+
+		tenant := parent.Clone()
+		tenant.ExtraParams.Set("tag", "tenant")
+		tenant.ExtraParams.Add("extra", "1")
+
+		if got := parent.ExtraParams.Encode(); got != "tag=parent" {
+			t.Errorf("Clone shared ExtraParams with the original, got %q", got)
+		}
+	})
+
+	t.Run("shares the endpoint resolver", func(t *testing.T) {
+		resolved := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[200],"success":true}`)
+		}))
+		defer resolved.Close()
+
+		routed := base.Clone()
+		routed.EndpointResolver = func(string) string { return resolved.URL }
+
+		data, err := routed.Clone().GetRandomUint8(1)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if data[0] != 200 {
+			t.Errorf("Expected the clone to use the resolved endpoint, got %d", data[0])
+		}
+	})
+}
+
+func TestEndpointResolver(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/uint8", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[8],"success":true}`)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"type":"uint16","length":1,"data":[16],"success":true}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+	client.EndpointResolver = func(dataType string) string {
+		if dataType == "uint8" {
+			return server.URL + "/uint8"
+		}
+		return ""
+	}
+
+	bytes, err := client.GetRandomUint8(1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	shorts, err := client.GetRandomUint16(1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if bytes[0] != 8 || shorts[0] != 16 {
+		t.Errorf("Expected uint8 from /uint8 and uint16 from the default endpoint, got %d and %d", bytes[0], shorts[0])
+	}
+}
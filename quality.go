@@ -0,0 +1,103 @@
+package qrng
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+)
+
+const (
+	defaultQualityAlpha = 0.01
+	// minMonobitBits is the sample size below which the monobit test is
+	// skipped, following NIST SP 800-22's n >= 100 recommendation
+	minMonobitBits = 100
+	// mcvZ is the 99% upper confidence bound multiplier of the SP 800-90B
+	// most common value estimate
+	mcvZ = 2.576
+)
+
+var ErrQualityCheckFailed = errors.New("batch failed monobit frequency test")
+
+// WithQualityCheck runs the NIST SP 800-22 monobit frequency test on every
+// batch of at least 100 bits and rejects batches whose p-value falls below
+// alpha. Rejected batches are treated as transient errors and retried when
+// retries are enabled. An alpha outside (0, 1) selects the default of 0.01.
+//
+// This is a smoke test that catches gross failures such as a stuck-at-zero
+// stream; it is not a certification of randomness. Note that a healthy
+// source still fails about alpha of the time by chance.
+func WithQualityCheck(alpha float64) Option {
+	if !(alpha > 0 && alpha < 1) {
+		alpha = defaultQualityAlpha
+	}
+	return func(c *QRNGClient) {
+		c.qualityAlpha = alpha
+	}
+}
+
+// valueBits returns the width in bits of each value the API returns for
+// dataType
+func valueBits(dataType string, params url.Values) int {
+	switch dataType {
+	case "uint16", "hex16":
+		return 16
+	case "hex8":
+		if size, err := strconv.Atoi(params.Get("size")); err == nil && size > 0 {
+			return 8 * size
+		}
+	}
+	return 8
+}
+
+// monobitCheck fails when the proportion of ones in data deviates from 1/2
+// more than chance allows at significance alpha
+func monobitCheck(data []int, width int, alpha float64) error {
+	// values wider than an int are clamped to 63 bits
+	width = min(width, 63)
+
+	n := len(data) * width
+	if n < minMonobitBits {
+		return nil
+	}
+
+	sum := 0
+	for _, v := range data {
+		for i := 0; i < width; i++ {
+			sum += 2*((v>>i)&1) - 1
+		}
+	}
+
+	sObs := math.Abs(float64(sum)) / math.Sqrt(float64(n))
+	p := math.Erfc(sObs / math.Sqrt2)
+	if p < alpha {
+		return fmt.Errorf("%w: p-value %.3g below %.3g", ErrQualityCheckFailed, p, alpha)
+	}
+	return nil
+}
+
+// EstimateMinEntropy returns the min-entropy of data in bits per byte using
+// the most common value estimator of NIST SP 800-90B section 6.3.1: the
+// frequency of the commonest byte, raised to its 99% upper confidence
+// bound, gives p_u and the estimate is -log2(p_u). The result ranges from 0
+// for a constant sample to at most 8, and approaches 8 for an ideal source
+// only as the sample grows; SP 800-90B expects at least a million samples.
+// Samples of fewer than two bytes return 0.
+func EstimateMinEntropy(data []byte) float64 {
+	n := len(data)
+	if n < 2 {
+		return 0
+	}
+
+	var counts [256]int
+	mode := 0
+	for _, b := range data {
+		counts[b]++
+		mode = max(mode, counts[b])
+	}
+
+	p := float64(mode) / float64(n)
+	pu := min(1, p+mcvZ*math.Sqrt(p*(1-p)/float64(n-1)))
+	return -math.Log2(pu)
+}
@@ -0,0 +1,116 @@
+package qrng_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func repeatedBody(value, count int) string {
+	return fmt.Sprintf(`{"type":"uint8","length":%d,"data":[%s],"success":true}`,
+		count, strings.TrimSuffix(strings.Repeat(fmt.Sprintf("%d,", value), count), ","))
+}
+
+func TestWithQualityCheck(t *testing.T) {
+	t.Run("stuck stream rejected", func(t *testing.T) {
+		server := newStaticServer(t, repeatedBody(0, 32))
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithQualityCheck(0.01))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(32); !errors.Is(err, qrng.ErrQualityCheckFailed) {
+			t.Errorf("Expected ErrQualityCheckFailed, got %v", err)
+		}
+	})
+
+	t.Run("balanced stream accepted", func(t *testing.T) {
+		// 0x55 has four ones in every byte
+		server := newStaticServer(t, repeatedBody(0x55, 32))
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithQualityCheck(0.01))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(32); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("small batches skipped", func(t *testing.T) {
+		server := newStaticServer(t, repeatedBody(0, 4))
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithQualityCheck(0.01))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(4); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("failed batch is retried", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				fmt.Fprintln(w, repeatedBody(0, 32))
+				return
+			}
+			fmt.Fprintln(w, repeatedBody(0x55, 32))
+		}))
+		defer server.Close()
+
+		client := qrng.NewClient(
+			qrng.WithQualityCheck(0.01),
+			qrng.WithRetries(1),
+			qrng.WithBackoff(qrng.ConstantBackoff{}),
+		)
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(32); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 calls, got %d", calls)
+		}
+	})
+}
+
+func TestEstimateMinEntropy(t *testing.T) {
+	uniform := make([]byte, 1024)
+	for i := range uniform {
+		uniform[i] = byte(i)
+	}
+	skewed := make([]byte, 1000)
+	for i := range skewed {
+		if i%2 == 1 {
+			skewed[i] = byte(i)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected float64
+	}{
+		{"every byte four times", uniform, 6.807105145329006},
+		{"constant", make([]byte, 100), 0},
+		{"too short", []byte{7}, 0},
+		// half the sample is zero: p = 0.5 + 2.576*sqrt(0.25/999)
+		{"skewed", skewed, -math.Log2(0.5 + 2.576*math.Sqrt(0.25/999))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := qrng.EstimateMinEntropy(tt.data); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
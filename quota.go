@@ -0,0 +1,70 @@
+package qrng
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrQuotaExceeded = errors.New("client-side request quota exceeded")
+
+// quota counts API requests against a budget that resets every window
+type quota struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	used   int
+	start  time.Time
+}
+
+// WithQuota caps the client at limit API requests per window and fails
+// further calls with ErrQuotaExceeded without contacting the API. Every HTTP
+// attempt counts, retries included, since that is what the ANU quota meters;
+// values served from the buffered pool do not. The count resets once window
+// has elapsed since the first request of the current window; a window of zero
+// or less never resets.
+//
+// The budget is shared by clones of the client unless the clone is given its
+// own WithQuota.
+func WithQuota(limit int, window time.Duration) Option {
+	return func(c *QRNGClient) {
+		c.quota = &quota{limit: max(limit, 0), window: window}
+	}
+}
+
+// RemainingQuota returns the number of requests left in the current window,
+// or -1 if no quota is configured
+func (c *QRNGClient) RemainingQuota() int {
+	if c.quota == nil {
+		return -1
+	}
+
+	q := c.quota
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.resetLocked(time.Now())
+	return q.limit - q.used
+}
+
+// reserve consumes one request from the budget, failing if none is left
+func (q *quota) reserve() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	q.resetLocked(now)
+	if q.used >= q.limit {
+		return ErrQuotaExceeded
+	}
+	if q.used == 0 {
+		q.start = now
+	}
+	q.used++
+	return nil
+}
+
+func (q *quota) resetLocked(now time.Time) {
+	if q.window > 0 && q.used > 0 && now.Sub(q.start) >= q.window {
+		q.used = 0
+	}
+}
@@ -0,0 +1,81 @@
+package qrng_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestWithQuota(t *testing.T) {
+	t.Run("blocks requests over the limit", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 0, http.StatusOK)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithQuota(2, time.Hour))
+		client.APIEndpoint = server.URL
+		var events int
+		client.OnRequest(func(qrng.RequestEvent) { events++ })
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.GetRandomUint8(1); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		if _, err := client.GetRandomUint8(1); !errors.Is(err, qrng.ErrQuotaExceeded) {
+			t.Errorf("Expected ErrQuotaExceeded, got %v", err)
+		}
+		if *calls != 2 || events != 2 {
+			t.Errorf("Expected 2 requests and events, got %d and %d", *calls, events)
+		}
+		if n := client.RemainingQuota(); n != 0 {
+			t.Errorf("Expected 0 remaining, got %d", n)
+		}
+	})
+
+	t.Run("resets after the window", func(t *testing.T) {
+		server, _ := newFlakyServer(t, 0, http.StatusOK)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithQuota(1, 50*time.Millisecond))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := client.GetRandomUint8(1); !errors.Is(err, qrng.ErrQuotaExceeded) {
+			t.Fatalf("Expected ErrQuotaExceeded, got %v", err)
+		}
+
+		time.Sleep(60 * time.Millisecond)
+		if n := client.RemainingQuota(); n != 1 {
+			t.Errorf("Expected 1 remaining, got %d", n)
+		}
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("retries count against the quota", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 5, http.StatusServiceUnavailable)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithQuota(2, time.Hour), qrng.WithRetries(5), qrng.WithBackoff(&recordingBackoff{}))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); !errors.Is(err, qrng.ErrQuotaExceeded) {
+			t.Errorf("Expected ErrQuotaExceeded, got %v", err)
+		}
+		if *calls != 2 {
+			t.Errorf("Expected 2 requests, got %d", *calls)
+		}
+	})
+
+	t.Run("unlimited without option", func(t *testing.T) {
+		if n := qrng.NewClient().RemainingQuota(); n != -1 {
+			t.Errorf("Expected -1, got %d", n)
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package qrng
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	maxJSONDepth = 16
+	maxJSONKeys  = 64
+	// maxJSONNodes caps the number of values in one document so that wide,
+	// deep limits cannot produce a document of maxKeys^maxDepth values
+	maxJSONNodes = 1000
+
+	// jsonEntropyBatch is the minimum number of bytes fetched at a time
+	// while building a document
+	jsonEntropyBatch = 256
+
+	maxJSONMagnitude = 1_000_000
+	maxJSONScale     = 3
+	maxJSONString    = 16
+	maxJSONKey       = 8
+)
+
+// RandomJSON returns a random JSON document for fuzzing JSON consumers.
+// Every value is chosen at random among null, booleans, numbers (integers
+// and decimals up to ±1000000 with at most three fractional digits),
+// printable ASCII strings, arrays and objects. Containers hold at most
+// maxKeys elements and nest at most maxDepth levels below the top-level
+// value, so maxDepth 0 always yields a scalar. Object keys are short
+// lowercase strings and are unique within an object. A document holds at
+// most 1000 values in total; once that budget is spent, further containers
+// are left empty.
+//
+// maxDepth must be between 0 and 16 and maxKeys between 0 and 64. Entropy
+// is fetched in batches as the document grows; bytes left over when it is
+// complete are discarded.
+func (c *QRNGClient) RandomJSON(maxDepth, maxKeys int) (json.RawMessage, error) {
+	if maxDepth < 0 || maxDepth > maxJSONDepth {
+		return nil, errors.New("maxDepth must be between 0 and 16")
+	}
+	if maxKeys < 0 || maxKeys > maxJSONKeys {
+		return nil, errors.New("maxKeys must be between 0 and 64")
+	}
+
+	g := &jsonGenerator{
+		c:        c,
+		ctx:      context.Background(),
+		maxDepth: maxDepth,
+		maxKeys:  maxKeys,
+		budget:   maxJSONNodes,
+	}
+	if err := g.value(0); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(g.out.Bytes()), nil
+}
+
+type jsonGenerator struct {
+	c        *QRNGClient
+	ctx      context.Context
+	entropy  []byte
+	maxDepth int
+	maxKeys  int
+	budget   int
+	out      bytes.Buffer
+}
+
+// read serves n bytes from the buffered entropy, refilling it as needed
+func (g *jsonGenerator) read(ctx context.Context, n int) ([]byte, error) {
+	if n > len(g.entropy) {
+		more, err := g.c.readBytes(ctx, max(n-len(g.entropy), jsonEntropyBatch))
+		if err != nil {
+			return nil, err
+		}
+		g.entropy = append(g.entropy, more...)
+	}
+	data := g.entropy[:n]
+	g.entropy = g.entropy[n:]
+	return data, nil
+}
+
+// intn returns an unbiased integer in [min, max]
+func (g *jsonGenerator) intn(min, max int) (int, error) {
+	vs, err := boundedFrom(g.ctx, []int{max - min}, g.read)
+	if err != nil {
+		return 0, err
+	}
+	return min + vs[0], nil
+}
+
+func (g *jsonGenerator) value(depth int) error {
+	g.budget--
+
+	kinds := 4
+	if depth < g.maxDepth {
+		kinds = 6
+	}
+	kind, err := g.intn(0, kinds-1)
+	if err != nil {
+		return err
+	}
+
+	switch kind {
+	case 0:
+		g.out.WriteString("null")
+	case 1:
+		b, err := g.intn(0, 1)
+		if err != nil {
+			return err
+		}
+		if b == 1 {
+			g.out.WriteString("true")
+		} else {
+			g.out.WriteString("false")
+		}
+	case 2:
+		return g.number()
+	case 3:
+		return g.str(0, maxJSONString, ' ', '~')
+	case 4:
+		return g.array(depth)
+	default:
+		return g.object(depth)
+	}
+	return nil
+}
+
+func (g *jsonGenerator) number() error {
+	scale, err := g.intn(0, maxJSONScale)
+	if err != nil {
+		return err
+	}
+	v, err := g.intn(-maxJSONMagnitude, maxJSONMagnitude)
+	if err != nil {
+		return err
+	}
+	g.out.WriteString(formatFixed(int64(v), scale))
+	return nil
+}
+
+// str writes a JSON string of minLen to maxLen characters in [lo, hi]
+func (g *jsonGenerator) str(minLen, maxLen int, lo, hi byte) error {
+	s, err := g.rawString(minLen, maxLen, lo, hi)
+	if err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	g.out.Write(encoded)
+	return nil
+}
+
+func (g *jsonGenerator) rawString(minLen, maxLen int, lo, hi byte) (string, error) {
+	n, err := g.intn(minLen, maxLen)
+	if err != nil {
+		return "", err
+	}
+	s := make([]byte, n)
+	for i := range s {
+		ch, err := g.intn(int(lo), int(hi))
+		if err != nil {
+			return "", err
+		}
+		s[i] = byte(ch)
+	}
+	return string(s), nil
+}
+
+func (g *jsonGenerator) array(depth int) error {
+	n, err := g.intn(0, g.maxKeys)
+	if err != nil {
+		return err
+	}
+
+	g.out.WriteByte('[')
+	for i := range n {
+		if g.budget <= 0 {
+			break
+		}
+		if i > 0 {
+			g.out.WriteByte(',')
+		}
+		if err := g.value(depth + 1); err != nil {
+			return err
+		}
+	}
+	g.out.WriteByte(']')
+	return nil
+}
+
+func (g *jsonGenerator) object(depth int) error {
+	n, err := g.intn(0, g.maxKeys)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, n)
+	g.out.WriteByte('{')
+	for range n {
+		if g.budget <= 0 {
+			break
+		}
+		key, err := g.rawString(1, maxJSONKey, 'a', 'z')
+		if err != nil {
+			return err
+		}
+		if seen[key] {
+			continue
+		}
+		if len(seen) > 0 {
+			g.out.WriteByte(',')
+		}
+		seen[key] = true
+
+		encoded, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		g.out.Write(encoded)
+		g.out.WriteByte(':')
+		if err := g.value(depth + 1); err != nil {
+			return err
+		}
+	}
+	g.out.WriteByte('}')
+	return nil
+}
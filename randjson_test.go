@@ -0,0 +1,72 @@
+package qrng_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+// jsonDepth returns how many containers deep doc nests below its top level
+func jsonDepth(t *testing.T, doc []byte) int {
+	t.Helper()
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	depth, deepest := 0, 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		switch tok {
+		case json.Delim('['), json.Delim('{'):
+			depth++
+			deepest = max(deepest, depth)
+		case json.Delim(']'), json.Delim('}'):
+			depth--
+		}
+	}
+	return max(deepest-1, 0)
+}
+
+func TestRandomJSON(t *testing.T) {
+	var calls int32
+	server := newCountingServer(t, &calls)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	t.Run("valid and bounded", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			doc, err := client.RandomJSON(3, 4)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !json.Valid(doc) {
+				t.Fatalf("Invalid JSON: %s", doc)
+			}
+			if d := jsonDepth(t, doc); d > 3 {
+				t.Errorf("Expected depth at most 3, got %d in %s", d, doc)
+			}
+		}
+	})
+
+	t.Run("depth zero is a scalar", func(t *testing.T) {
+		doc, err := client.RandomJSON(0, 10)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !json.Valid(doc) || doc[0] == '[' || doc[0] == '{' {
+			t.Errorf("Expected a scalar, got %s", doc)
+		}
+	})
+
+	t.Run("invalid bounds", func(t *testing.T) {
+		for _, tt := range [][2]int{{-1, 1}, {17, 1}, {1, -1}, {1, 65}} {
+			if _, err := client.RandomJSON(tt[0], tt[1]); err == nil {
+				t.Errorf("Expected error for maxDepth %d, maxKeys %d", tt[0], tt[1])
+			}
+		}
+	})
+}
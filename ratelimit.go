@@ -0,0 +1,66 @@
+package qrng
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rateLimitEpochThreshold separates reset headers holding a Unix timestamp
+// from those holding seconds until the reset; a delta this large would be
+// over 30 years
+const rateLimitEpochThreshold = 1_000_000_000
+
+// rateLimitStatus is the quota last reported by the server
+type rateLimitStatus struct {
+	remaining int
+	resetAt   time.Time
+	ok        bool
+}
+
+// RateLimitStatus returns the quota reported by the server in the most
+// recent response carrying an X-RateLimit-Remaining or RateLimit-Remaining
+// header, error responses such as 429 included. resetAt comes from the
+// matching Reset header, read as a Unix timestamp or as seconds from the
+// response, and is the zero time if none was sent. ok is false until a
+// response has reported a quota. Unlike RemainingQuota, this is the API's
+// own count.
+func (c *QRNGClient) RateLimitStatus() (remaining int, resetAt time.Time, ok bool) {
+	c.metaMu.Lock()
+	defer c.metaMu.Unlock()
+	return c.rateLimit.remaining, c.rateLimit.resetAt, c.rateLimit.ok
+}
+
+// observeRateLimit records the quota headers of resp, if it has any
+func (c *QRNGClient) observeRateLimit(resp *http.Response) {
+	remaining, ok := rateLimitHeader(resp.Header, "X-RateLimit-Remaining", "RateLimit-Remaining")
+	if !ok || remaining < 0 {
+		return
+	}
+
+	status := rateLimitStatus{remaining: int(remaining), ok: true}
+	if reset, ok := rateLimitHeader(resp.Header, "X-RateLimit-Reset", "RateLimit-Reset"); ok && reset >= 0 {
+		if reset >= rateLimitEpochThreshold {
+			status.resetAt = time.Unix(reset, 0)
+		} else {
+			status.resetAt = time.Now().Add(time.Duration(reset) * time.Second)
+		}
+	}
+
+	c.metaMu.Lock()
+	c.rateLimit = status
+	c.metaMu.Unlock()
+}
+
+// rateLimitHeader returns the first of names that holds an integer
+func rateLimitHeader(header http.Header, names ...string) (int64, bool) {
+	for _, name := range names {
+		if v := strings.TrimSpace(header.Get(name)); v != "" {
+			if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,78 @@
+package qrng_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestRateLimitStatus(t *testing.T) {
+	newServer := func(t *testing.T, status int, headers map[string]string) *httptest.Server {
+		t.Helper()
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for k, v := range headers {
+				w.Header().Set(k, v)
+			}
+			w.WriteHeader(status)
+			fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[1],"success":true}`)
+		}))
+	}
+
+	t.Run("absent headers", func(t *testing.T) {
+		server := newServer(t, http.StatusOK, nil)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, _, ok := client.RateLimitStatus(); ok {
+			t.Error("Expected ok=false without rate-limit headers")
+		}
+	})
+
+	t.Run("unix reset", func(t *testing.T) {
+		server := newServer(t, http.StatusOK, map[string]string{
+			"X-RateLimit-Remaining": "42",
+			"X-RateLimit-Reset":     "1893456000",
+		})
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		remaining, resetAt, ok := client.RateLimitStatus()
+		if !ok || remaining != 42 || !resetAt.Equal(time.Unix(1893456000, 0)) {
+			t.Errorf("Unexpected status: %d %v %v", remaining, resetAt, ok)
+		}
+	})
+
+	t.Run("delta reset on error response", func(t *testing.T) {
+		server := newServer(t, http.StatusForbidden, map[string]string{
+			"RateLimit-Remaining": "0",
+			"RateLimit-Reset":     "60",
+		})
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		before := time.Now()
+		if _, err := client.GetRandomUint8(1); err == nil {
+			t.Fatal("Expected error for 403 response")
+		}
+		remaining, resetAt, ok := client.RateLimitStatus()
+		if !ok || remaining != 0 {
+			t.Errorf("Unexpected status: %d %v", remaining, ok)
+		}
+		if resetAt.Before(before.Add(60*time.Second)) || resetAt.After(time.Now().Add(60*time.Second)) {
+			t.Errorf("Expected reset about 60s from now, got %v", resetAt)
+		}
+	})
+}
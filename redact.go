@@ -0,0 +1,56 @@
+package qrng
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// redactedValue replaces secret values in errors and the wire log
+const redactedValue = "[REDACTED]"
+
+// redactedError hides an API key in the message of the error it wraps.
+// Unwrap still returns the original so errors.Is and errors.As keep
+// working; only code that unwraps and prints the inner error itself can see
+// the key.
+type redactedError struct {
+	err error
+	key string
+}
+
+func (e *redactedError) Error() string {
+	return strings.ReplaceAll(e.err.Error(), e.key, redactedValue)
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactKey returns err with every occurrence of key in its message
+// replaced, for errors that may have picked up the key from a request URL,
+// an echoing server or a custom transport. It returns err unchanged when
+// there is nothing to hide.
+func redactKey(err error, key string) error {
+	if err == nil || key == "" || !strings.Contains(err.Error(), key) {
+		return err
+	}
+	return &redactedError{err: err, key: key}
+}
+
+// redactBody scrubs key from the response body an error carries, so a
+// *StatusError or *ParseError pulled out with errors.As does not hand an
+// echoed key back to the caller. The body is rewritten in place; it belongs
+// to the error and nothing else holds it.
+func redactBody(err error, key string) {
+	if err == nil || key == "" {
+		return
+	}
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		statusErr.Body = bytes.ReplaceAll(statusErr.Body, []byte(key), []byte(redactedValue))
+	}
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.Body = bytes.ReplaceAll(parseErr.Body, []byte(key), []byte(redactedValue))
+	}
+}
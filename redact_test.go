@@ -0,0 +1,88 @@
+package qrng_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+const secretKey = "sk-live-0123456789"
+
+func TestAPIKeyRedaction(t *testing.T) {
+	t.Run("transport error", func(t *testing.T) {
+		errDial := errors.New("dial failed")
+		rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			return nil, fmt.Errorf("%w with key %s", errDial, r.Header.Get("x-api-key"))
+		})
+
+		var events []qrng.RequestEvent
+		client := qrng.NewClientWithAPIKey(secretKey, qrng.WithTransport(rt), qrng.WithRetries(0))
+		client.OnRequest(func(ev qrng.RequestEvent) { events = append(events, ev) })
+
+		_, err := client.GetRandomUint8(1)
+		if err == nil {
+			t.Fatal("Expected error from failing transport")
+		}
+		if strings.Contains(err.Error(), secretKey) {
+			t.Errorf("API key leaked into error: %v", err)
+		}
+		if !strings.Contains(err.Error(), "[REDACTED]") {
+			t.Errorf("Expected redaction marker, got %v", err)
+		}
+		if !errors.Is(err, errDial) {
+			t.Errorf("Expected error chain to be preserved, got %v", err)
+		}
+		if len(events) != 1 || strings.Contains(events[0].Err.Error(), secretKey) {
+			t.Errorf("API key leaked into request event: %v", events)
+		}
+	})
+
+	t.Run("echoed in error response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid key "+r.Header.Get("x-api-key"), http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		client := qrng.NewClientWithAPIKey(secretKey)
+		client.APIEndpoint = server.URL
+
+		_, err := client.GetRandomUint8(1)
+		if err == nil {
+			t.Fatal("Expected error for 403 response")
+		}
+		if strings.Contains(err.Error(), secretKey) {
+			t.Errorf("API key leaked into error: %v", err)
+		}
+		var statusErr *qrng.StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden {
+			t.Fatalf("Expected *StatusError with 403, got %v", err)
+		}
+		if strings.Contains(statusErr.Error(), secretKey) || strings.Contains(string(statusErr.Body), secretKey) {
+			t.Errorf("API key leaked into StatusError: %v", statusErr)
+		}
+	})
+
+	t.Run("echoed in unparseable body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "<html>key %s rejected</html>", r.Header.Get("x-api-key"))
+		}))
+		defer server.Close()
+
+		client := qrng.NewClientWithAPIKey(secretKey)
+		client.APIEndpoint = server.URL
+
+		_, err := client.GetRandomUint8(1)
+		var parseErr *qrng.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Expected *ParseError, got %v", err)
+		}
+		if strings.Contains(parseErr.Error(), secretKey) || strings.Contains(string(parseErr.Body), secretKey) {
+			t.Errorf("API key leaked into ParseError: %v", parseErr)
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package qrng
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+const replayEndpoint = "http://replay.invalid"
+
+var (
+	ErrReplayExhausted = errors.New("replay log exhausted")
+	ErrReplayMismatch  = errors.New("request does not match replay log")
+)
+
+// recordedBatch is one line of a replay log
+type recordedBatch struct {
+	Type   string `json:"type"`
+	Length int    `json:"length"`
+	Data   []int  `json:"data"`
+}
+
+type recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (r *recorder) record(dataType string, length int, data []int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enc.Encode(recordedBatch{Type: dataType, Length: length, Data: data})
+}
+
+// WithRecorder writes every batch returned by the API to w as one JSON object
+// per line, suitable for NewReplayClient. The log contains raw entropy, so
+// treat it as sensitive and never enable this for key generation.
+func WithRecorder(w io.Writer) Option {
+	return func(c *QRNGClient) {
+		c.recorder = &recorder{enc: json.NewEncoder(w)}
+	}
+}
+
+// NewReplayClient creates a client that serves the batches recorded by
+// WithRecorder, in order, instead of contacting the API. The same sequence of
+// calls that produced the log yields exactly the same results.
+func NewReplayClient(r io.Reader, opts ...Option) (*QRNGClient, error) {
+	var batches []recordedBatch
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var b recordedBatch
+		if err := json.Unmarshal(line, &b); err != nil {
+			return nil, fmt.Errorf("invalid replay log: %w", err)
+		}
+		batches = append(batches, b)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading replay log: %w", err)
+	}
+
+	c := &QRNGClient{
+		APIEndpoint: replayEndpoint,
+		HTTPClient: &http.Client{
+			Transport: &replayTransport{batches: batches},
+		},
+	}
+	c.applyOptions(opts)
+	return c, nil
+}
+
+type replayTransport struct {
+	mu      sync.Mutex
+	batches []recordedBatch
+}
+
+func (t *replayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.batches) == 0 {
+		return nil, ErrReplayExhausted
+	}
+
+	q := req.URL.Query()
+	next := t.batches[0]
+	if q.Get("type") != next.Type || q.Get("length") != strconv.Itoa(next.Length) {
+		return nil, fmt.Errorf("%w: got type=%s length=%s, want type=%s length=%d",
+			ErrReplayMismatch, q.Get("type"), q.Get("length"), next.Type, next.Length)
+	}
+	t.batches = t.batches[1:]
+
+	body, err := json.Marshal(QRNGResponse{
+		Type:    next.Type,
+		Length:  next.Length,
+		Data:    next.Data,
+		Success: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package qrng_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":3,"data":[9,8,7],"success":true}`)
+	defer server.Close()
+
+	var log bytes.Buffer
+	client := qrng.NewClient(qrng.WithRecorder(&log))
+	client.APIEndpoint = server.URL
+
+	original, err := client.GetRandomUint8(3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	num, err := client.GetRandomNumber(0, 9)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("replays recorded sequence", func(t *testing.T) {
+		replay, err := qrng.NewReplayClient(bytes.NewReader(log.Bytes()))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		got, err := replay.GetRandomUint8(3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(original) {
+			t.Errorf("Expected %v, got %v", original, got)
+		}
+
+		gotNum, err := replay.GetRandomNumber(0, 9)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if gotNum != num {
+			t.Errorf("Expected %d, got %d", num, gotNum)
+		}
+
+		if _, err := replay.GetRandomUint8(1); !errors.Is(err, qrng.ErrReplayExhausted) {
+			t.Errorf("Expected ErrReplayExhausted, got %v", err)
+		}
+	})
+
+	t.Run("mismatched request", func(t *testing.T) {
+		replay, err := qrng.NewReplayClient(bytes.NewReader(log.Bytes()))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := replay.GetRandomUint16(3); !errors.Is(err, qrng.ErrReplayMismatch) {
+			t.Errorf("Expected ErrReplayMismatch, got %v", err)
+		}
+	})
+
+	t.Run("invalid log", func(t *testing.T) {
+		if _, err := qrng.NewReplayClient(bytes.NewReader([]byte("not json\n"))); err == nil {
+			t.Error("Expected error for invalid log")
+		}
+	})
+}
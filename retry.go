@@ -0,0 +1,156 @@
+package qrng
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultBackoffBase  = 250 * time.Millisecond
+	defaultBackoffLimit = 5 * time.Second
+)
+
+// BackoffStrategy decides how long to wait before a retry. attempt is 1 for
+// the first retry, 2 for the second, and so on.
+type BackoffStrategy interface {
+	NextDelay(attempt int) time.Duration
+}
+
+// ExponentialBackoff doubles the delay on every attempt, starting at Base and
+// never exceeding Max (when Max is positive)
+type ExponentialBackoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func (b ExponentialBackoff) NextDelay(attempt int) time.Duration {
+	delay := b.Base
+	for i := 1; i < attempt && delay < math.MaxInt64/2; i++ {
+		delay *= 2
+	}
+	if b.Max > 0 && delay > b.Max {
+		return b.Max
+	}
+	return delay
+}
+
+// ConstantBackoff waits the same Delay before every retry
+type ConstantBackoff struct {
+	Delay time.Duration
+}
+
+func (b ConstantBackoff) NextDelay(int) time.Duration {
+	return b.Delay
+}
+
+func defaultBackoff() BackoffStrategy {
+	return ExponentialBackoff{Base: defaultBackoffBase, Max: defaultBackoffLimit}
+}
+
+// WithRetries retries transient failures (network errors, response bodies
+// cut short by a dropped or stalled connection, 429 and 5xx responses) up to
+// maxRetries times. Without WithBackoff the delay grows exponentially from
+// 250ms up to 5s.
+func WithRetries(maxRetries int) Option {
+	return func(c *QRNGClient) {
+		c.maxRetries = maxRetries
+	}
+}
+
+// WithBackoff sets the delay schedule between retries. If WithRetries is not
+// also given, up to 3 retries are made.
+func WithBackoff(strategy BackoffStrategy) Option {
+	return func(c *QRNGClient) {
+		c.backoff = strategy
+	}
+}
+
+// retryableError marks a failure that the retry loop may try again
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+func isRetryable(err error) bool {
+	var re *retryableError
+	return errors.As(err, &re)
+}
+
+// isTruncatedRead reports whether an error reading a response body means the
+// connection dropped or stalled part-way through
+func isTruncatedRead(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isTruncatedBody reports whether a parse error comes from body ending
+// before the document did, as when a connection without a Content-Length
+// closes early
+func isTruncatedBody(err error, body []byte) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var syntaxErr *json.SyntaxError
+	return errors.As(err, &syntaxErr) && syntaxErr.Offset >= int64(len(bytes.TrimSpace(body)))
+}
+
+// isPermanentTransportError reports whether a failed request can never
+// succeed on retry: a transport option that could not be applied, a server
+// certificate that fails verification or does not match the WithTLSPin
+// fingerprints, or a replay log that has run out or diverged
+func isPermanentTransportError(err error) bool {
+	var cfgErr *transportConfigError
+	var verifyErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &cfgErr) ||
+		errors.Is(err, ErrTLSPinMismatch) ||
+		errors.As(err, &verifyErr) ||
+		errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.Is(err, ErrReplayExhausted) ||
+		errors.Is(err, ErrReplayMismatch)
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,260 @@
+package qrng_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+type recordingBackoff struct {
+	attempts []int
+}
+
+func (b *recordingBackoff) NextDelay(attempt int) time.Duration {
+	b.attempts = append(b.attempts, attempt)
+	return time.Millisecond
+}
+
+func newFlakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[1],"success":true}`)
+	}))
+	return server, &calls
+}
+
+func TestBackoffStrategies(t *testing.T) {
+	t.Run("exponential", func(t *testing.T) {
+		b := qrng.ExponentialBackoff{Base: 100 * time.Millisecond, Max: time.Second}
+		expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+		for i, want := range expected {
+			if got := b.NextDelay(i + 1); got != want {
+				t.Errorf("attempt %d: expected %v, got %v", i+1, want, got)
+			}
+		}
+	})
+
+	t.Run("constant", func(t *testing.T) {
+		b := qrng.ConstantBackoff{Delay: 3 * time.Millisecond}
+		if b.NextDelay(1) != 3*time.Millisecond || b.NextDelay(9) != 3*time.Millisecond {
+			t.Error("ConstantBackoff should not vary")
+		}
+	})
+}
+
+func TestRetries(t *testing.T) {
+	t.Run("custom backoff retries transient errors", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 2, http.StatusServiceUnavailable)
+		defer server.Close()
+
+		backoff := &recordingBackoff{}
+		client := qrng.NewClient(qrng.WithBackoff(backoff))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if *calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", *calls)
+		}
+		if fmt.Sprint(backoff.attempts) != "[1 2]" {
+			t.Errorf("Expected attempts [1 2], got %v", backoff.attempts)
+		}
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 10, http.StatusBadGateway)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithRetries(1), qrng.WithBackoff(qrng.ConstantBackoff{}))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err == nil {
+			t.Fatal("Expected error after retries exhausted")
+		}
+		if *calls != 2 {
+			t.Errorf("Expected 2 calls, got %d", *calls)
+		}
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		server, calls := newFlakyServer(t, 10, http.StatusBadRequest)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithRetries(3), qrng.WithBackoff(qrng.ConstantBackoff{}))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err == nil {
+			t.Fatal("Expected error")
+		}
+		if *calls != 1 {
+			t.Errorf("Expected 1 call, got %d", *calls)
+		}
+	})
+
+	t.Run("wait respects context cancellation", func(t *testing.T) {
+		server, _ := newFlakyServer(t, 10, http.StatusServiceUnavailable)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithBackoff(qrng.ConstantBackoff{Delay: time.Hour}))
+		client.APIEndpoint = server.URL
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := client.GetRandomUint8Context(ctx, 1)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
+
+// newTruncatingServer cuts the connection part-way through the body on the
+// first failures requests. With declareLength the response announces its
+// full Content-Length, so the client sees an unexpected EOF; without it the
+// body just ends early.
+func newTruncatingServer(t *testing.T, failures int32, declareLength bool) (*httptest.Server, *int32) {
+	t.Helper()
+	body := `{"type":"uint8","length":1,"data":[1],"success":true}`
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) > failures {
+			fmt.Fprint(w, body)
+			return
+		}
+
+		conn, buf, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		fmt.Fprint(buf, "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nConnection: close\r\n")
+		if declareLength {
+			fmt.Fprintf(buf, "Content-Length: %d\r\n", len(body))
+		}
+		fmt.Fprint(buf, "\r\n", body[:20])
+		buf.Flush()
+	}))
+	return server, &calls
+}
+
+func TestTruncatedBodyRetried(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		declareLength bool
+	}{
+		{"unexpected EOF", true},
+		{"body ends early", false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server, calls := newTruncatingServer(t, 2, tt.declareLength)
+			defer server.Close()
+
+			backoff := &recordingBackoff{}
+			client := qrng.NewClient(qrng.WithRetries(3), qrng.WithBackoff(backoff))
+			client.APIEndpoint = server.URL
+
+			data, err := client.GetRandomUint8(1)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if data[0] != 1 || *calls != 3 {
+				t.Errorf("Expected [1] after 3 calls, got %v after %d", data, *calls)
+			}
+		})
+	}
+
+	t.Run("surfaced without retries", func(t *testing.T) {
+		server, _ := newTruncatingServer(t, 1, true)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Expected io.ErrUnexpectedEOF, got %v", err)
+		}
+	})
+}
+
+func TestPermanentTransportErrorsNotRetried(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[7],"success":true}`)
+	}))
+	defer server.Close()
+
+	unsupported := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("should not be called")
+	})
+
+	tests := []struct {
+		name     string
+		opts     []qrng.Option
+		endpoint string
+		expected error
+	}{
+		{"unsupported transport", []qrng.Option{qrng.WithTransport(unsupported), qrng.WithForceHTTP1()}, "", qrng.ErrUnsupportedTransport},
+		{"invalid network", []qrng.Option{qrng.WithNetwork("udp")}, "", qrng.ErrInvalidNetwork},
+		{"invalid pool settings", []qrng.Option{qrng.WithConnectionPool(-1, 0, 0)}, "", nil},
+		{"invalid pin", []qrng.Option{qrng.WithTransport(server.Client().Transport), qrng.WithTLSPin("00")}, "", qrng.ErrInvalidTLSPin},
+		{"pin mismatch", []qrng.Option{qrng.WithTransport(server.Client().Transport), qrng.WithTLSPin(strings.Repeat("0", 64))}, "", qrng.ErrTLSPinMismatch},
+		{"untrusted certificate", nil, "", nil},
+		{"unsupported scheme", nil, "ftp://example.com", nil},
+		{"missing host", nil, "https://", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backoff := &recordingBackoff{}
+			client := qrng.NewClient(append(tt.opts, qrng.WithRetries(3), qrng.WithBackoff(backoff))...)
+			client.APIEndpoint = server.URL
+			if tt.endpoint != "" {
+				client.APIEndpoint = tt.endpoint
+			}
+			var attempts int
+			client.OnRequest(func(qrng.RequestEvent) { attempts++ })
+
+			_, err := client.GetRandomUint8(1)
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			if tt.expected != nil && !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+			if len(backoff.attempts) != 0 || attempts != 1 {
+				t.Errorf("Expected a single attempt, got %d attempts and %d retries", attempts, len(backoff.attempts))
+			}
+		})
+	}
+
+	t.Run("replay exhausted", func(t *testing.T) {
+		backoff := &recordingBackoff{}
+		client, err := qrng.NewReplayClient(strings.NewReader(""), qrng.WithRetries(3), qrng.WithBackoff(backoff))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if _, err := client.GetRandomUint8(1); !errors.Is(err, qrng.ErrReplayExhausted) {
+			t.Errorf("Expected ErrReplayExhausted, got %v", err)
+		}
+		if len(backoff.attempts) != 0 {
+			t.Errorf("Expected no retries, got %d", len(backoff.attempts))
+		}
+	})
+}
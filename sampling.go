@@ -0,0 +1,149 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// probabilityTolerance is how far a probability vector may sum from 1
+const probabilityTolerance = 1e-6
+
+var ErrInvalidDistribution = errors.New("probabilities must be non-negative and sum to 1")
+
+// cumulative validates probs and returns their running sums
+func cumulative(probs []float64) ([]float64, error) {
+	if len(probs) == 0 {
+		return nil, ErrInvalidDistribution
+	}
+
+	cum := make([]float64, len(probs))
+	total := 0.0
+	for i, p := range probs {
+		if !(p >= 0) || math.IsInf(p, 1) {
+			return nil, ErrInvalidDistribution
+		}
+		total += p
+		cum[i] = total
+	}
+
+	if math.Abs(total-1) > probabilityTolerance {
+		return nil, fmt.Errorf("%w: sum is %v", ErrInvalidDistribution, total)
+	}
+	return cum, nil
+}
+
+// categoricalIndex maps u in [0,1) to the index whose cumulative interval
+// contains it. Rounding slack at the top falls to the last non-zero entry.
+func categoricalIndex(cum []float64, u float64) int {
+	u *= cum[len(cum)-1]
+	for i, c := range cum {
+		if u < c {
+			return i
+		}
+	}
+	for i := len(cum) - 1; i > 0; i-- {
+		if cum[i] > cum[i-1] {
+			return i
+		}
+	}
+	return 0
+}
+
+// sampleDistinct returns k distinct integers drawn uniformly from [0, n)
+// in selection order, using a partial Fisher–Yates shuffle over a sparse
+// map so n can be large. All draws are fetched in one batch.
+func (c *QRNGClient) sampleDistinct(ctx context.Context, n, k int) ([]int, error) {
+	bounds := make([]int, k)
+	for s := range bounds {
+		bounds[s] = n - 1 - s
+	}
+	js, err := c.randomBounded(ctx, bounds)
+	if err != nil {
+		return nil, err
+	}
+
+	// swapped holds the value at each position moved by an earlier step
+	swapped := make(map[int]int, k)
+	at := func(i int) int {
+		if v, ok := swapped[i]; ok {
+			return v
+		}
+		return i
+	}
+
+	picks := make([]int, k)
+	for s, j := range js {
+		j += s
+		picks[s] = at(j)
+		swapped[j] = at(s)
+	}
+	return picks, nil
+}
+
+// Categorical returns a key of probs sampled with its associated
+// probability. Probabilities must be non-negative and sum to 1 within 1e-6.
+// Keys are ordered by their fmt representation before building the
+// cumulative distribution, so the same uniform draw always selects the same
+// key regardless of map iteration order.
+func Categorical[T comparable](client *QRNGClient, probs map[T]float64) (T, error) {
+	var zero T
+
+	keys := make([]T, 0, len(probs))
+	for k := range probs {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b T) int {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+
+	weights := make([]float64, len(keys))
+	for i, k := range keys {
+		weights[i] = probs[k]
+	}
+
+	cum, err := cumulative(weights)
+	if err != nil {
+		return zero, err
+	}
+
+	u, err := client.uniforms(context.Background(), 1)
+	if err != nil {
+		return zero, err
+	}
+	return keys[categoricalIndex(cum, u[0])], nil
+}
+
+// BernoulliSample keeps each item independently with probability probs[i]
+// (Poisson sampling), so the result size varies between calls. One uniform
+// is drawn per item, all in a single batch, and the kept items preserve
+// their input order.
+func BernoulliSample[T any](client *QRNGClient, items []T, probs []float64) ([]T, error) {
+	if len(items) != len(probs) {
+		return nil, fmt.Errorf("got %d probabilities for %d items", len(probs), len(items))
+	}
+	for _, p := range probs {
+		if !(p >= 0 && p <= 1) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidProbability, p)
+		}
+	}
+	if len(items) == 0 {
+		return []T{}, nil
+	}
+
+	u, err := client.uniforms(context.Background(), len(items))
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]T, 0, len(items))
+	for i, item := range items {
+		if u[i] < probs[i] {
+			kept = append(kept, item)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,72 @@
+package qrng_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestCategorical(t *testing.T) {
+	probs := map[string]float64{"rain": 0.2, "cloud": 0.3, "sun": 0.5}
+
+	// keys sort as cloud [0,0.3), rain [0.3,0.5), sun [0.5,1)
+	for _, tc := range []struct {
+		u    float64
+		want string
+	}{{0.1, "cloud"}, {0.4, "rain"}, {0.5, "sun"}, {0.99, "sun"}} {
+		server := uniformSequenceServer(t, tc.u)
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		got, err := qrng.Categorical(client, probs)
+		server.Close()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if got != tc.want {
+			t.Errorf("u=%v: expected %s, got %s", tc.u, tc.want, got)
+		}
+	}
+
+	t.Run("invalid distributions", func(t *testing.T) {
+		client := qrng.NewClient()
+		for _, p := range []map[int]float64{
+			{},
+			{1: 0.5, 2: 0.4},
+			{1: 1.5, 2: -0.5},
+		} {
+			if _, err := qrng.Categorical(client, p); !errors.Is(err, qrng.ErrInvalidDistribution) {
+				t.Errorf("Expected ErrInvalidDistribution for %v, got %v", p, err)
+			}
+		}
+	})
+}
+
+func TestBernoulliSample(t *testing.T) {
+	server := uniformSequenceServer(t, 0.5, 0.5, 0.1, 0.99)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	items := []string{"a", "b", "c", "d"}
+	got, err := qrng.BernoulliSample(client, items, []float64{0.6, 0.5, 0, 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fmt.Sprint(got) != "[a d]" {
+		t.Errorf("Expected [a d], got %v", got)
+	}
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		if _, err := qrng.BernoulliSample(client, items, []float64{0.5}); err == nil {
+			t.Error("Expected error for length mismatch")
+		}
+		if _, err := qrng.BernoulliSample(client, []int{1}, []float64{1.5}); !errors.Is(err, qrng.ErrInvalidProbability) {
+			t.Errorf("Expected ErrInvalidProbability, got %v", err)
+		}
+	})
+}
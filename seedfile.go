@@ -0,0 +1,104 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// seedFileMode restricts persisted entropy to the owning user
+const seedFileMode = 0o600
+
+var ErrNoSeedFile = errors.New("client has no seed file configured")
+
+// WithSeedFile pre-loads the buffered pool with quantum bytes saved by an
+// earlier SaveSeedFile, so the client can serve a bounded amount of data
+// while the API is unreachable. The pool is used for those bytes even when
+// BufferSize is zero. A missing file is not an error.
+//
+// Loaded bytes are removed from disk immediately, so a restart never serves
+// them twice; if the file cannot be read or removed, every request fails
+// with that error. Clones do not inherit the seed file or its bytes.
+//
+// Persisting entropy trades secrecy for availability: anyone who can read
+// the file before it is consumed knows the values the client will return.
+// Keep it on a local filesystem, readable only by the service account
+// (SaveSeedFile creates it with mode 0600), and do not use seeded clients
+// for cryptographic keys.
+func WithSeedFile(path string) Option {
+	return func(c *QRNGClient) {
+		c.seedFile = path
+		c.seedErr = nil
+
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return
+		}
+		if err == nil {
+			err = os.Remove(path)
+		}
+		if err != nil {
+			c.seedErr = fmt.Errorf("seed file: %w", err)
+			return
+		}
+
+		c.mu.Lock()
+		c.pool = append(c.pool, data...)
+		c.mu.Unlock()
+	}
+}
+
+// SaveSeedFile moves the unused bytes of the buffered pool into the seed
+// file configured with WithSeedFile, first topping the pool up from the API
+// until it holds at least minBytes. Saved bytes leave the pool so they are
+// never served both now and after a restart. The file is replaced
+// atomically and created with mode 0600. ctx must not carry a
+// ContextWithAPIKey key: the saved bytes are served to every caller.
+func (c *QRNGClient) SaveSeedFile(ctx context.Context, minBytes int) error {
+	if c.seedFile == "" {
+		return ErrNoSeedFile
+	}
+	if minBytes < 0 {
+		return errors.New("minBytes must not be negative")
+	}
+	if _, perKey := apiKeyFromContext(ctx); perKey {
+		return errors.New("seed file cannot be filled with a per-request API key")
+	}
+
+	ctx, cancel := withTimeout(ctx, c.Timeouts.Bulk)
+	defer cancel()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.fillPoolLocked(ctx, minBytes); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.seedFile), ".qrng-seed-*")
+	if err != nil {
+		return fmt.Errorf("seed file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(seedFileMode); err != nil {
+		tmp.Close()
+		return fmt.Errorf("seed file: %w", err)
+	}
+	if _, err := tmp.Write(c.pool); err != nil {
+		tmp.Close()
+		return fmt.Errorf("seed file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("seed file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.seedFile); err != nil {
+		return fmt.Errorf("seed file: %w", err)
+	}
+
+	c.pool = nil
+	return nil
+}
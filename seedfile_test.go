@@ -0,0 +1,111 @@
+package qrng_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestWithSeedFile(t *testing.T) {
+	t.Run("serves and consumes saved bytes", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		path := filepath.Join(t.TempDir(), "seed.bin")
+		if err := os.WriteFile(path, []byte{9, 8, 7}, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		client := qrng.NewClient(qrng.WithSeedFile(path))
+		client.APIEndpoint = server.URL
+
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected seed file to be consumed, stat returned %v", err)
+		}
+
+		data, err := client.GetRandomUint8(2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(data) != "[9 8]" || calls != 0 {
+			t.Errorf("Expected [9 8] offline, got %v after %d requests", data, calls)
+		}
+
+		data, err = client.GetRandomUint8(2)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(data) != "[7 0]" || calls != 1 {
+			t.Errorf("Expected [7 0] after 1 request, got %v after %d", data, calls)
+		}
+	})
+
+	t.Run("save tops up and drains the pool", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		path := filepath.Join(t.TempDir(), "seed.bin")
+		client := qrng.NewClient(qrng.WithSeedFile(path))
+		client.APIEndpoint = server.URL
+
+		if err := client.SaveSeedFile(context.Background(), 4); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		saved, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if fmt.Sprint(saved) != "[0 1 2 3]" {
+			t.Errorf("Expected [0 1 2 3] saved, got %v", saved)
+		}
+		if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+			t.Errorf("Expected mode 0600, got %v (%v)", info.Mode().Perm(), err)
+		}
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected saved bytes to leave the pool, made %d requests", calls)
+		}
+	})
+
+	t.Run("unreadable file fails requests", func(t *testing.T) {
+		client := qrng.NewClient(qrng.WithSeedFile(t.TempDir()))
+		if _, err := client.GetRandomUint8(1); err == nil {
+			t.Error("Expected seed file error")
+		}
+	})
+
+	t.Run("save rejects a per-request key", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient(qrng.WithSeedFile(filepath.Join(t.TempDir(), "seed.bin")))
+		client.APIEndpoint = server.URL
+
+		ctx := qrng.ContextWithAPIKey(context.Background(), "tenant")
+		if err := client.SaveSeedFile(ctx, 4); err == nil {
+			t.Error("Expected error for per-request API key")
+		}
+		if calls != 0 {
+			t.Errorf("Expected no requests, made %d", calls)
+		}
+	})
+
+	t.Run("save requires a seed file", func(t *testing.T) {
+		err := qrng.NewClient().SaveSeedFile(context.Background(), 0)
+		if !errors.Is(err, qrng.ErrNoSeedFile) {
+			t.Errorf("Expected ErrNoSeedFile, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+)
+
+// minStreamBatch is the smallest batch StreamUint8 fetches, so a small or
+// unbuffered channel does not cost one request of quota per byte
+const minStreamBatch = 64
+
+// StreamUint8 returns a channel that yields random bytes until ctx is
+// cancelled or a request fails. The channel holds up to bufferSize values,
+// zero or less meaning unbuffered; a slow consumer applies backpressure
+// because the producer blocks on send and only fetches the next batch once
+// the previous one has been delivered. Batches are bufferSize bytes, but at
+// least 64 and at most the per-request limit.
+//
+// If the consumer stops reading, the producer stays blocked without fetching
+// more data; cancel ctx to release it. Bytes already fetched but not yet
+// delivered are discarded on cancellation.
+//
+// The error channel receives at most one error, the context error or the
+// request error that ended the stream, and is closed along with the data
+// channel.
+func (c *QRNGClient) StreamUint8(ctx context.Context, bufferSize int) (<-chan uint8, <-chan error) {
+	out := make(chan uint8, max(bufferSize, 0))
+	errc := make(chan error, 1)
+
+	batch := min(max(bufferSize, minStreamBatch), c.maxLength())
+
+	go func() {
+		defer close(out)
+		defer close(errc)
+
+		for {
+			if err := ctx.Err(); err != nil {
+				errc <- err
+				return
+			}
+
+			data, err := c.fetchUint8(ctx, batch)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+					err = ctxErr
+				}
+				errc <- err
+				return
+			}
+
+			for _, b := range data {
+				select {
+				case out <- b:
+				case <-ctx.Done():
+					errc <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+
+	return out, errc
+}
+
+// Bytes returns an endless sequence of random bytes, fetched from the API in
+// batches of up to the per-request limit as the consumer advances. The
+// sequence ends when the consumer stops ranging over it, or after yielding a
+// single non-nil error when a request fails or ctx is cancelled. Bytes left
+// over from a batch when the consumer stops are discarded.
+func (c *QRNGClient) Bytes(ctx context.Context) iter.Seq2[byte, error] {
+	return func(yield func(byte, error) bool) {
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(0, err)
+				return
+			}
+
+			data, err := c.fetchUint8(ctx, c.maxLength())
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+					err = ctxErr
+				}
+				yield(0, err)
+				return
+			}
+
+			for _, b := range data {
+				if !yield(b, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// WriteRandom writes total random bytes to w, fetching them in chunks of up to
+// the API maximum. It stops at the first fetch or write error, or when ctx is
+// cancelled, and returns the number of bytes written so far.
+func (c *QRNGClient) WriteRandom(ctx context.Context, w io.Writer, total int) (int, error) {
+	if total < 0 {
+		return 0, errors.New("total must not be negative")
+	}
+
+	ctx, cancel := withTimeout(ctx, c.Timeouts.Bulk)
+	defer cancel()
+
+	written := 0
+	for written < total {
+		if err := ctx.Err(); err != nil {
+			return written, err
+		}
+
+		data, err := c.fetchUint8(ctx, min(total-written, c.maxLength()))
+		if err != nil {
+			return written, err
+		}
+
+		n, err := w.Write(data[:min(len(data), total-written)])
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
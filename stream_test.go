@@ -0,0 +1,192 @@
+package qrng_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestStreamUint8(t *testing.T) {
+	t.Run("yields bytes until cancelled", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		ctx, cancel := context.WithCancel(context.Background())
+		out, errc := client.StreamUint8(ctx, 4)
+
+		for i := 0; i < 6; i++ {
+			b := <-out
+			if int(b) != i {
+				t.Errorf("Expected %d, got %d", i, b)
+			}
+		}
+		cancel()
+
+		for range out {
+		}
+		if err := <-errc; !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+
+	t.Run("unbuffered stream fetches in batches", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		out, _ := client.StreamUint8(ctx, 0)
+
+		for i := 0; i < 10; i++ {
+			<-out
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Errorf("Expected 1 request for 10 bytes, made %d", n)
+		}
+	})
+
+	t.Run("backpressure limits fetching", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		out, _ := client.StreamUint8(ctx, 4)
+
+		<-out
+		time.Sleep(50 * time.Millisecond)
+		if n := atomic.LoadInt32(&calls); n > 2 {
+			t.Errorf("Expected producer to block, made %d requests", n)
+		}
+	})
+}
+
+type failingWriter struct {
+	limit int
+	n     int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.n+len(p) > w.limit {
+		n := w.limit - w.n
+		w.n = w.limit
+		return n, errors.New("disk full")
+	}
+	w.n += len(p)
+	return len(p), nil
+}
+
+func TestWriteRandom(t *testing.T) {
+	t.Run("writes total bytes", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		var buf bytes.Buffer
+		n, err := client.WriteRandom(context.Background(), &buf, 2500)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if n != 2500 || buf.Len() != 2500 {
+			t.Errorf("Expected 2500 bytes, wrote %d (buffer %d)", n, buf.Len())
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 requests, got %d", calls)
+		}
+	})
+
+	t.Run("stops on write error", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		n, err := client.WriteRandom(context.Background(), &failingWriter{limit: 1500}, 4000)
+		if err == nil {
+			t.Fatal("Expected write error")
+		}
+		if n != 1500 {
+			t.Errorf("Expected 1500 bytes written, got %d", n)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 requests, got %d", calls)
+		}
+	})
+
+	t.Run("stops on cancellation", func(t *testing.T) {
+		client := qrng.NewClient()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		n, err := client.WriteRandom(ctx, &bytes.Buffer{}, 10)
+		if !errors.Is(err, context.Canceled) || n != 0 {
+			t.Errorf("Expected (0, context.Canceled), got (%d, %v)", n, err)
+		}
+	})
+}
+
+func TestBytes(t *testing.T) {
+	t.Run("yields bytes until the consumer stops", func(t *testing.T) {
+		var calls int32
+		server := newCountingServer(t, &calls)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		var got []byte
+		for b, err := range client.Bytes(context.Background()) {
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			got = append(got, b)
+			if len(got) == 1500 {
+				break
+			}
+		}
+		for i, b := range got {
+			if int(b) != i%1024%256 {
+				t.Fatalf("Expected %d at index %d, got %d", i%1024%256, i, b)
+			}
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 requests, got %d", calls)
+		}
+	})
+
+	t.Run("yields the context error", func(t *testing.T) {
+		client := qrng.NewClient()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var errs []error
+		for _, err := range client.Bytes(ctx) {
+			errs = append(errs, err)
+		}
+		if len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+			t.Errorf("Expected a single context.Canceled, got %v", errs)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package qrng
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// GetRandomWeekday returns a weekday uniform over Sunday through Saturday
+func (c *QRNGClient) GetRandomWeekday() (time.Weekday, error) {
+	days, err := c.randomInts(context.Background(), int(time.Sunday), int(time.Saturday), 1)
+	if err != nil {
+		return 0, err
+	}
+	return time.Weekday(days[0]), nil
+}
+
+// GetRandomDateInYear returns midnight UTC on a day uniform over the valid
+// days of year (1-9999), so leap years have 366 candidates
+func (c *QRNGClient) GetRandomDateInYear(year int) (time.Time, error) {
+	if year < 1 || year > 9999 {
+		return time.Time{}, errors.New("year must be between 1 and 9999")
+	}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	numDays := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+
+	offsets, err := c.randomInts(context.Background(), 0, numDays-1, 1)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return start.AddDate(0, 0, offsets[0]), nil
+}
+
+// GetRandomDuration returns a duration uniform over [min, max] at
+// nanosecond resolution. On platforms where int is 32 bits, bounds beyond
+// about 2.1s fail with ErrRangeTooLarge.
+func (c *QRNGClient) GetRandomDuration(min, max time.Duration) (time.Duration, error) {
+	return c.randomDuration(context.Background(), min, max)
+}
+
+func (c *QRNGClient) randomDuration(ctx context.Context, min, max time.Duration) (time.Duration, error) {
+	if !fitsInt(int64(min)) || !fitsInt(int64(max)) {
+		return 0, ErrRangeTooLarge
+	}
+	ds, err := c.randomInts(ctx, int(min), int(max), 1)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ds[0]), nil
+}
+
+// RandomSleep blocks for a duration drawn as by GetRandomDuration. It
+// returns early with the context's error if ctx is done first, whether
+// during the fetch or the sleep.
+func (c *QRNGClient) RandomSleep(ctx context.Context, min, max time.Duration) error {
+	if min < 0 {
+		return errors.New("min must not be negative")
+	}
+
+	d, err := c.randomDuration(ctx, min, max)
+	if err != nil {
+		return err
+	}
+	return sleepContext(ctx, d)
+}
@@ -0,0 +1,140 @@
+package qrng_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestGetRandomWeekday(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":1,"data":[5],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	day, err := client.GetRandomWeekday()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if day != time.Friday {
+		t.Errorf("Expected Friday, got %v", day)
+	}
+}
+
+func TestGetRandomDateInYear(t *testing.T) {
+	t.Run("leap day reachable", func(t *testing.T) {
+		// offset 59 is Feb 29 in a leap year; 366 days need a 9-bit mask
+		server := newStaticServer(t, `{"type":"uint8","length":2,"data":[0,59],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		date, err := client.GetRandomDateInYear(2024)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if date.Month() != time.February || date.Day() != 29 {
+			t.Errorf("Expected Feb 29, got %v", date)
+		}
+	})
+
+	t.Run("last day of common year", func(t *testing.T) {
+		server := newStaticServer(t, `{"type":"uint8","length":2,"data":[1,108],"success":true}`)
+		defer server.Close()
+
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		date, err := client.GetRandomDateInYear(2023)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if date.Month() != time.December || date.Day() != 31 {
+			t.Errorf("Expected Dec 31, got %v", date)
+		}
+	})
+
+	t.Run("invalid year", func(t *testing.T) {
+		client := qrng.NewClient()
+		if _, err := client.GetRandomDateInYear(0); err == nil {
+			t.Error("Expected error for year 0")
+		}
+	})
+}
+
+func TestGetRandomDuration(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":1,"data":[2],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	t.Run("offset from min", func(t *testing.T) {
+		d, err := client.GetRandomDuration(10*time.Millisecond, 10*time.Millisecond+3)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if want := 10*time.Millisecond + 2; d != want {
+			t.Errorf("Expected %v, got %v", want, d)
+		}
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		if _, err := client.GetRandomDuration(time.Second, time.Millisecond); !errors.Is(err, qrng.ErrInvalidRange) {
+			t.Errorf("Expected ErrInvalidRange, got %v", err)
+		}
+	})
+
+	t.Run("bounds beyond int", func(t *testing.T) {
+		if strconv.IntSize == 64 {
+			t.Skip("int holds every duration")
+		}
+		if _, err := client.GetRandomDuration(0, time.Minute); !errors.Is(err, qrng.ErrRangeTooLarge) {
+			t.Errorf("Expected ErrRangeTooLarge, got %v", err)
+		}
+	})
+}
+
+func TestRandomSleep(t *testing.T) {
+	server := newStaticServer(t, `{"type":"uint8","length":1,"data":[2],"success":true}`)
+	defer server.Close()
+
+	client := qrng.NewClient()
+	client.APIEndpoint = server.URL
+
+	t.Run("sleeps the drawn duration", func(t *testing.T) {
+		start := time.Now()
+		if err := client.RandomSleep(context.Background(), 20*time.Millisecond, 20*time.Millisecond+3); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < 20*time.Millisecond {
+			t.Errorf("Expected at least 20ms, slept %v", elapsed)
+		}
+	})
+
+	t.Run("cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := client.RandomSleep(ctx, time.Hour, time.Hour+3)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Expected early return, slept %v", elapsed)
+		}
+	})
+
+	t.Run("negative min", func(t *testing.T) {
+		if err := client.RandomSleep(context.Background(), -time.Second, time.Second); err == nil {
+			t.Error("Expected error for negative min")
+		}
+	})
+}
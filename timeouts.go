@@ -0,0 +1,37 @@
+package qrng
+
+import (
+	"context"
+	"time"
+)
+
+// Timeouts sets default deadlines per kind of operation. Each applies only
+// when the caller's context has no deadline of its own, and a zero value
+// leaves that kind of operation bounded just by HTTPClient.Timeout, which
+// limits every HTTP attempt separately.
+type Timeouts struct {
+	// Request bounds a call served by one API request, retries and backoff
+	// included, such as GetRandomUint16 or GetRandomUint8.
+	Request time.Duration
+
+	// Bulk bounds, as a whole, a call that spans several API requests:
+	// byte, bit and number draws larger than the per-request maximum,
+	// WriteRandom, FetchAll and SaveSeedFile. Requests inside a bulk call
+	// share its deadline instead of getting their own Request timeout.
+	Bulk time.Duration
+
+	// Probe bounds CheckAvailability, DiscoverLimits and Warmup.
+	Probe time.Duration
+}
+
+// withTimeout derives a context bounded by d unless d is unset or ctx
+// already carries a deadline
+func withTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
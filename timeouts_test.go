@@ -0,0 +1,90 @@
+package qrng_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+// newSlowServer answers every request after delay, or gives up when the
+// client does
+func newSlowServer(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		length, _ := strconv.Atoi(r.URL.Query().Get("length"))
+		fmt.Fprintln(w, repeatedBody(7, length))
+	}))
+}
+
+func TestTimeouts(t *testing.T) {
+	server := newSlowServer(t, 200*time.Millisecond)
+	defer server.Close()
+
+	t.Run("request timeout", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.Timeouts.Request = 20 * time.Millisecond
+
+		if _, err := client.GetRandomUint8(1); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("explicit deadline wins", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.Timeouts.Request = 20 * time.Millisecond
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := client.GetRandomUint8Context(ctx, 1); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("bulk timeout spans requests", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		// each request fits the per-request budget, but two do not fit the bulk one
+		client.Timeouts.Request = time.Second
+		client.Timeouts.Bulk = 300 * time.Millisecond
+
+		if _, err := client.GetRandomBytes(2048); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+		if _, err := client.GetRandomBytes(16); err != nil {
+			t.Errorf("Unexpected error for single-request draw: %v", err)
+		}
+	})
+
+	t.Run("probe timeout", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+		client.Timeouts.Probe = 20 * time.Millisecond
+
+		if err := client.CheckAvailability(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+
+	t.Run("unset keeps waiting", func(t *testing.T) {
+		client := qrng.NewClient()
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package qrng
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StatusError reports a non-200 response from the API
+type StatusError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// statusError drains resp.Body into the error returned for a non-200 response
+func statusError(resp *http.Response) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unexpected status code %d: error reading body: %w", resp.StatusCode, err)
+	}
+	return &StatusError{StatusCode: resp.StatusCode, Body: body}
+}
+
+// Transport wraps base with the client's ANU-specific behaviour: it injects
+// the x-api-key header (honouring ContextWithAPIKey) when the request does
+// not already carry one, and turns non-200 responses into a *StatusError.
+// The result can be stacked with other RoundTripper middleware and passed
+// back via WithTransport or HTTPClient. A nil base uses
+// http.DefaultTransport. When the result is passed to WithTransport,
+// transport options such as WithTLSPin configure base, which must then be
+// an *http.Transport or nil.
+//
+// Unlike a plain RoundTripper it interprets the response status, so place it
+// inside middleware that expects to see raw error responses.
+func (c *QRNGClient) Transport(base http.RoundTripper) http.RoundTripper {
+	return &apiTransport{
+		apiKey: func() (string, bool) { return c.APIKey, c.requiresAPIKey() },
+		base:   base,
+	}
+}
+
+// NewTransport is Transport for building middleware without a client: it
+// injects apiKey, unless empty, in place of a client's key.
+func NewTransport(base http.RoundTripper, apiKey string) http.RoundTripper {
+	return &apiTransport{
+		apiKey: func() (string, bool) { return apiKey, apiKey != "" },
+		base:   base,
+	}
+}
+
+type apiTransport struct {
+	// apiKey returns the key to inject and whether to inject one at all
+	apiKey func() (string, bool)
+	base   http.RoundTripper
+}
+
+func (t *apiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if apiKey, ok := t.apiKey(); ok && req.Header.Get("x-api-key") == "" {
+		if key, ok := apiKeyFromContext(req.Context()); ok {
+			apiKey = key
+		}
+		req = req.Clone(req.Context())
+		req.Header.Set("x-api-key", apiKey)
+	}
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, statusError(resp)
+	}
+	return resp, nil
+}
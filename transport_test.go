@@ -0,0 +1,80 @@
+package qrng_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "forbidden")
+			return
+		}
+		fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[1],"success":true}`)
+	}))
+	defer server.Close()
+
+	t.Run("injects key for plain http client", func(t *testing.T) {
+		client := qrng.NewClientWithAPIKey("secret")
+
+		var seen int
+		logging := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			seen++
+			return http.DefaultTransport.RoundTrip(r)
+		})
+		hc := &http.Client{Transport: client.Transport(logging)}
+
+		resp, err := hc.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if seen != 1 {
+			t.Errorf("Expected inner middleware to run once, ran %d times", seen)
+		}
+	})
+
+	t.Run("transport options configure the wrapped base", func(t *testing.T) {
+		for name, rt := range map[string]http.RoundTripper{
+			"method":   qrng.NewClientWithAPIKey("secret").Transport(nil),
+			"function": qrng.NewTransport(&http.Transport{}, "secret"),
+		} {
+			t.Run(name, func(t *testing.T) {
+				client := qrng.NewClient(
+					qrng.WithTransport(rt),
+					qrng.WithNetwork("tcp4"),
+					qrng.WithConnectionPool(2, 2, time.Second),
+					qrng.WithForceHTTP1(),
+				)
+				client.APIEndpoint = server.URL
+
+				if _, err := client.GetRandomUint8(1); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			})
+		}
+	})
+
+	t.Run("maps status errors", func(t *testing.T) {
+		client := qrng.NewClientWithAPIKey("wrong")
+		client.HTTPClient.Transport = client.Transport(nil)
+		client.APIEndpoint = server.URL
+
+		_, err := client.GetRandomUint8(1)
+		var statusErr *qrng.StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusForbidden {
+			t.Fatalf("Expected StatusError 403, got %v", err)
+		}
+		if string(statusErr.Body) != "forbidden" {
+			t.Errorf("Expected body forbidden, got %q", statusErr.Body)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package qrng
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+type wireLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// WithWireLog writes a plain-text trace of every HTTP attempt to w: the
+// request line and headers as sent by the client, prefixed "> ", then the
+// response status line and headers, prefixed "< ", followed by the raw
+// response body. The x-api-key header is always redacted. Headers added
+// later by the transport itself, such as User-Agent, are not shown.
+//
+// The trace contains the random data returned by the API, so it is meant
+// for support tickets and debugging only; never enable it for key
+// generation. Each exchange is written with a single Write call.
+func WithWireLog(w io.Writer) Option {
+	return func(c *QRNGClient) {
+		c.wireLog = nil
+		if w != nil {
+			c.wireLog = &wireLog{w: w}
+		}
+	}
+}
+
+// exchange writes one request and its outcome. resp is nil when the request
+// failed before a response arrived, in which case err describes why; body
+// is whatever part of the response body was read.
+func (l *wireLog) exchange(req *http.Request, resp *http.Response, body []byte, err error) {
+	// the negotiated version is only known from the response
+	proto := req.Proto
+	if resp != nil {
+		proto = resp.Proto
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "> %s %s %s\n", req.Method, req.URL.RequestURI(), proto)
+	fmt.Fprintf(&buf, "> Host: %s\n", req.URL.Host)
+	writeHeaders(&buf, "> ", req.Header)
+	buf.WriteString(">\n")
+
+	if resp == nil {
+		fmt.Fprintf(&buf, "< error: %v\n\n", err)
+	} else {
+		fmt.Fprintf(&buf, "< %s %s\n", resp.Proto, resp.Status)
+		writeHeaders(&buf, "< ", resp.Header)
+		buf.WriteString("<\n")
+		buf.Write(body)
+		if len(body) == 0 || body[len(body)-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+		buf.WriteByte('\n')
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.w.Write(buf.Bytes())
+}
+
+func writeHeaders(buf *bytes.Buffer, prefix string, header http.Header) {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		for _, v := range header[k] {
+			if http.CanonicalHeaderKey(k) == "X-Api-Key" {
+				v = redactedValue
+			}
+			fmt.Fprintf(buf, "%s%s: %s\n", prefix, k, v)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package qrng_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	qrng "github.com/albertnieto/anu-qrng-go"
+)
+
+func TestWithWireLog(t *testing.T) {
+	t.Run("traces request and response with the key redacted", func(t *testing.T) {
+		body := `{"type":"uint8","length":1,"data":[7],"success":true}`
+		server := newStaticServer(t, body)
+		defer server.Close()
+
+		var log bytes.Buffer
+		client := qrng.NewClientWithAPIKey("secret-key", qrng.WithWireLog(&log))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		trace := log.String()
+		if strings.Contains(trace, "secret-key") {
+			t.Errorf("API key leaked into wire log:\n%s", trace)
+		}
+		for _, want := range []string{
+			"> GET /?",
+			"length=1",
+			"> X-Api-Key: [REDACTED]",
+			"< HTTP/1.1 200 OK",
+			body,
+		} {
+			if !strings.Contains(trace, want) {
+				t.Errorf("Expected wire log to contain %q, got:\n%s", want, trace)
+			}
+		}
+	})
+
+	t.Run("logs error responses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad request", http.StatusBadRequest)
+		}))
+		defer server.Close()
+
+		var log bytes.Buffer
+		client := qrng.NewClient(qrng.WithWireLog(&log))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err == nil {
+			t.Fatal("Expected error for 400 response")
+		}
+		if trace := log.String(); !strings.Contains(trace, "< HTTP/1.1 400 Bad Request") || !strings.Contains(trace, "bad request") {
+			t.Errorf("Unexpected wire log:\n%s", trace)
+		}
+	})
+
+	t.Run("reports the negotiated protocol", func(t *testing.T) {
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintln(w, `{"type":"uint8","length":1,"data":[7],"success":true}`)
+		}))
+		server.EnableHTTP2 = true
+		server.StartTLS()
+		defer server.Close()
+
+		var log bytes.Buffer
+		client := qrng.NewClient(qrng.WithTransport(server.Client().Transport), qrng.WithWireLog(&log))
+		client.APIEndpoint = server.URL
+
+		if _, err := client.GetRandomUint8(1); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		trace := log.String()
+		if !strings.Contains(trace, "type=uint8 HTTP/2.0\n") || !strings.Contains(trace, "< HTTP/2.0 200 OK") {
+			t.Errorf("Expected HTTP/2.0 in the wire log, got:\n%s", trace)
+		}
+	})
+
+	t.Run("logs transport errors", func(t *testing.T) {
+		var log bytes.Buffer
+		client := qrng.NewClient(qrng.WithWireLog(&log), qrng.WithRetries(0))
+		client.APIEndpoint = "http://127.0.0.1:0"
+
+		if _, err := client.GetRandomUint8(1); err == nil {
+			t.Fatal("Expected error for unreachable endpoint")
+		}
+		if trace := log.String(); !strings.Contains(trace, "< error: ") {
+			t.Errorf("Unexpected wire log:\n%s", trace)
+		}
+	})
+}